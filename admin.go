@@ -0,0 +1,171 @@
+package ticketing
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"strings"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// MAX_ADMIN_PAGE_SIZE caps the page size of ViewAdminDetails. Larger page
+// sizes are reduced to it.
+const MAX_ADMIN_PAGE_SIZE = 1000
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// adminQuery selects and orders the seats shown by ViewAdminDetails.
+type adminQuery struct {
+	req        *v1.ViewAdminDetailsRequest
+	hash       uint64
+	after      *v1.AdminPageToken // Position of the last seat on the previous page, if any
+	departures map[string]int32   // Position of each departure in the schedule
+}
+
+// newAdminQuery checks a ViewAdminDetailsRequest and decodes its page token.
+func (h *MyTrainTicketingServiceHandler) newAdminQuery(req *v1.ViewAdminDetailsRequest) (*adminQuery, error) {
+	if req.GetPageSize() < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("page size must not be negative"))
+	}
+	after, before := req.GetPurchasedAfter(), req.GetPurchasedBefore()
+	if after != nil && before != nil && !after.AsTime().Before(before.AsTime()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("purchased_after must be before purchased_before"))
+	}
+
+	q := &adminQuery{req: req, departures: make(map[string]int32)}
+	for i, info := range h.schedule {
+		q.departures[info.GetId()] = int32(i)
+	}
+
+	// Tokens are tied to the filters and sort order, but not the page size
+	filters := proto.Clone(req).(*v1.ViewAdminDetailsRequest)
+	filters.PageSize = 0
+	filters.PageToken = ""
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(filters)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	sum := sha256.Sum256(data)
+	q.hash = binary.BigEndian.Uint64(sum[:8])
+
+	if req.GetPageToken() != "" {
+		data, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errInvalidPageToken)
+		}
+		q.after = &v1.AdminPageToken{}
+		if err := proto.Unmarshal(data, q.after); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errInvalidPageToken)
+		}
+		if q.after.GetQuery() != q.hash {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("page token was issued for different filters or sort order"))
+		}
+	}
+	return q, nil
+}
+
+// matches reports whether a booking passes the query's filters.
+func (q *adminQuery) matches(r *v1.Receipt) bool {
+	ticket := r.GetTicket()
+	if section := q.req.GetSection().GetSectionType(); section != v1.Section_SECTION_TYPE_UNSPECIFIED && sectionOf(ticket.GetSeat().GetSeatNumber()) != section {
+		return false
+	}
+	if id := q.req.GetDepartureId(); id != "" && ticket.GetDepartureId() != id {
+		return false
+	}
+	if email := q.req.GetEmailContains(); email != "" && !strings.Contains(strings.ToLower(ticket.GetUser().GetEmail()), strings.ToLower(email)) {
+		return false
+	}
+	if after := q.req.GetPurchasedAfter(); after != nil && r.GetPurchasedAt().AsTime().Before(after.AsTime()) {
+		return false
+	}
+	if before := q.req.GetPurchasedBefore(); before != nil && !r.GetPurchasedAt().AsTime().Before(before.AsTime()) {
+		return false
+	}
+	if code := q.req.GetDiscountCode(); code != "" && ticket.GetDiscountCode() != code {
+		return false
+	}
+	return true
+}
+
+// key returns the position of a booking in the query's sort order.
+func (q *adminQuery) key(r *v1.Receipt) *v1.AdminPageToken {
+	ticket := r.GetTicket()
+	key := &v1.AdminPageToken{Query: q.hash, BookingId: r.GetBookingId()}
+	switch q.req.GetSortOrder() {
+	case v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT, v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT_DESC:
+		key.Time = r.GetPurchasedAt().AsTime().UnixNano()
+	case v1.AdminSortOrder_ADMIN_SORT_ORDER_NAME:
+		key.Text = ticket.GetUser().GetLastName() + "\x00" + ticket.GetUser().GetFirstName()
+	case v1.AdminSortOrder_ADMIN_SORT_ORDER_EMAIL:
+		key.Text = ticket.GetUser().GetEmail()
+	default:
+		key.Departure = q.departures[ticket.GetDepartureId()]
+		key.SeatNumber = ticket.GetSeat().GetSeatNumber()
+	}
+	return key
+}
+
+// less reports whether key a comes before key b.
+func (q *adminQuery) less(a, b *v1.AdminPageToken) bool {
+	if q.req.GetSortOrder() == v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT_DESC {
+		a, b = b, a
+	}
+	switch {
+	case a.GetText() != b.GetText():
+		return a.GetText() < b.GetText()
+	case a.GetTime() != b.GetTime():
+		return a.GetTime() < b.GetTime()
+	case a.GetDeparture() != b.GetDeparture():
+		return a.GetDeparture() < b.GetDeparture()
+	case a.GetSeatNumber() != b.GetSeatNumber():
+		return a.GetSeatNumber() < b.GetSeatNumber()
+	}
+	return a.GetBookingId() < b.GetBookingId()
+}
+
+// page filters and sorts bookings and returns the requested page of them,
+// along with the token for the next page.
+func (q *adminQuery) page(bookings []*v1.Receipt) (*v1.AdminView, string, error) {
+	type entry struct {
+		receipt *v1.Receipt
+		key     *v1.AdminPageToken
+	}
+	var entries []entry
+	for _, r := range bookings {
+		if !q.matches(r) {
+			continue
+		}
+		// Skip everything up to and including the end of the previous page
+		key := q.key(r)
+		if q.after != nil && !q.less(q.after, key) {
+			continue
+		}
+		entries = append(entries, entry{r, key})
+	}
+	sort.Slice(entries, func(i, j int) bool { return q.less(entries[i].key, entries[j].key) })
+
+	var next string
+	if size := int(min(q.req.GetPageSize(), MAX_ADMIN_PAGE_SIZE)); size > 0 && len(entries) > size {
+		entries = entries[:size]
+		data, err := proto.Marshal(entries[size-1].key)
+		if err != nil {
+			return nil, "", connect.NewError(connect.CodeInternal, err)
+		}
+		next = base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	view := &v1.AdminView{}
+	for _, e := range entries {
+		seat := e.receipt.GetTicket().GetSeat()
+		view.Seats = append(view.Seats, seat)
+		view.Users = append(view.Users, seat.GetUser())
+		view.Bookings = append(view.Bookings, e.receipt)
+	}
+	return view, next, nil
+}
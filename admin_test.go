@@ -0,0 +1,165 @@
+package ticketing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// adminEmails returns the emails on every page of the admin view, fetching
+// req.PageSize seats at a time.
+func adminEmails(t *testing.T, client ticketingv1.TrainTicketingServiceClient, req *v1.ViewAdminDetailsRequest) []string {
+	t.Helper()
+	var emails []string
+	for {
+		res, err := client.ViewAdminDetails(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			t.Fatalf("ViewAdminDetails failed: %v", err)
+		}
+		for _, user := range res.Msg.GetAdminView().GetUsers() {
+			emails = append(emails, user.GetEmail())
+		}
+		if res.Msg.GetNextPageToken() == "" {
+			return emails
+		}
+		req.PageToken = res.Msg.GetNextPageToken()
+	}
+}
+
+func assertEmails(t *testing.T, want, got []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestViewAdminDetailsFiltersAndSorts(t *testing.T) {
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := &testClock{now: start}
+	client := newTestClient(t, server.WithClock(clock.Now), testDepartures(start.Add(30*24*time.Hour)))
+	for _, p := range []struct{ first, last, email, departureID, discountCode string }{
+		{"Jane", "Roe", "jane@example.com", "morning", ""},
+		{"John", "Doe", "john@Example.org", "evening", "WOW1"},
+		{"Mary", "Major", "mary@example.com", "morning", "WOW1"},
+		{"Adam", "Doe", "adam@example.org", "evening", ""},
+	} {
+		clock.Advance(time.Hour)
+		purchaseTicket(t, client, p.first, p.last, p.email, onDeparture(p.departureID), withDiscount(p.discountCode))
+	}
+
+	// By default seats are in schedule order and then by seat number
+	assertEmails(t, []string{"jane@example.com", "mary@example.com", "john@Example.org", "adam@example.org"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{}))
+
+	assertEmails(t, []string{"adam@example.org", "john@Example.org", "mary@example.com", "jane@example.com"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{SortOrder: v1.AdminSortOrder_ADMIN_SORT_ORDER_NAME}))
+	assertEmails(t, []string{"adam@example.org", "mary@example.com", "john@Example.org", "jane@example.com"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{SortOrder: v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT_DESC}))
+
+	assertEmails(t, []string{"john@Example.org", "adam@example.org"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{DepartureId: "evening"}))
+	assertEmails(t, []string{"adam@example.org", "john@Example.org"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{EmailContains: "EXAMPLE.ORG", SortOrder: v1.AdminSortOrder_ADMIN_SORT_ORDER_EMAIL}))
+	assertEmails(t, []string{"john@Example.org", "mary@example.com"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{DiscountCode: "WOW1", SortOrder: v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT}))
+
+	// Bookings were made an hour apart, starting an hour after start
+	assertEmails(t, []string{"john@Example.org", "mary@example.com"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{
+			PurchasedAfter:  timestamppb.New(start.Add(2 * time.Hour)),
+			PurchasedBefore: timestamppb.New(start.Add(4 * time.Hour)),
+			SortOrder:       v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT,
+		}))
+
+	// Seats 1-10 are in section A, so only filtering by section B finds nobody
+	if emails := adminEmails(t, client, &v1.ViewAdminDetailsRequest{Section: &v1.Section{SectionType: v1.Section_SECTION_TYPE_B}}); len(emails) != 0 {
+		t.Fatalf("expected nobody in section B, got %v", emails)
+	}
+}
+
+func TestViewAdminDetailsPageTokensSurviveChanges(t *testing.T) {
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)))
+	var ids []string
+	for _, name := range []string{"Ann", "Bob", "Cat", "Dan", "Eve"} {
+		ids = append(ids, purchaseTicket(t, client, name, "Lee", name+"@example.com", onDeparture("morning")))
+	}
+
+	// Every seat shows up exactly once across the pages
+	assertEmails(t, []string{"Ann@example.com", "Bob@example.com", "Cat@example.com", "Dan@example.com", "Eve@example.com"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{PageSize: 2}))
+
+	res, err := client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{PageSize: 2}))
+	if err != nil {
+		t.Fatalf("ViewAdminDetails failed: %v", err)
+	}
+	token := res.Msg.GetNextPageToken()
+
+	// Cancelling a seat already seen and booking the freed seat doesn't move
+	// the later pages
+	if _, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: ids[0]})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	purchaseTicket(t, client, "Fay", "Lee", "Fay@example.com", onDeparture("morning"))
+	assertEmails(t, []string{"Cat@example.com", "Dan@example.com", "Eve@example.com"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{PageSize: 2, PageToken: token}))
+
+	// A token only works with the filters it was issued for
+	_, err = client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{PageSize: 2, PageToken: token, DepartureId: "morning"}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for a token from other filters, got %v", err)
+	}
+	_, err = client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{PageToken: "not a token"}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for a malformed token, got %v", err)
+	}
+}
+
+func TestAdminCallsNeedAdmin(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)))
+	agent := newClient(srv, signJWT(t, "agent-7", "agent"))
+	purchaseTicket(t, agent, "Jane", "Roe", "jane@example.com")
+
+	for _, tc := range []struct {
+		name string
+		call func() error
+	}{
+		{"ViewAdminDetails", func() error {
+			_, err := agent.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+			return err
+		}},
+		{"RemoveUser", func() error {
+			_, err := agent.RemoveUser(context.Background(), connect.NewRequest(&v1.RemoveUserRequest{User: &v1.User{FirstName: "Jane"}}))
+			return err
+		}},
+		{"ModifySeat", func() error {
+			_, err := agent.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{User: &v1.User{FirstName: "Jane"}, NewSeatNumber: 15}))
+			return err
+		}},
+	} {
+		if err := tc.call(); connect.CodeOf(err) != connect.CodePermissionDenied {
+			t.Errorf("expected PermissionDenied calling %s without the admin role, got %v", tc.name, err)
+		}
+	}
+
+	// Nothing was changed, and admins may make the calls
+	admin := newClient(srv, signJWT(t, "alice", "admin"))
+	if seats := takenSeats(t, admin, ""); len(seats) != 1 || seats[0] != 1 {
+		t.Fatalf("expected Jane to keep seat 1, got %v", seats)
+	}
+	if _, err := admin.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{User: &v1.User{FirstName: "Jane"}, NewSeatNumber: 15})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+}
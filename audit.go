@@ -0,0 +1,363 @@
+package ticketing
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+)
+
+// AuditLog is an append-only log of the admin and mutating calls served by a
+// handler: who made each one, whether it succeeded and how it changed
+// bookings.
+type AuditLog interface {
+	// Append records an event. Events must not be modified once appended.
+	Append(event *v1.AuditEvent) error
+	// Replay calls fn for every event appended so far, oldest first, stopping
+	// at the first error.
+	Replay(fn func(*v1.AuditEvent) error) error
+}
+
+// unauditedProcedures are the calls that don't change bookings and are open to
+// passengers, so they are left out of the audit log.
+var unauditedProcedures = map[string]bool{
+	ticketingv1.TrainTicketingServiceViewReceiptProcedure: true,
+	ticketingv1.TrainTicketingServiceViewSeatMapProcedure: true,
+}
+
+// WithAuditLog sets the audit log calls are written to. Events already in the
+// log are kept, and new ones numbered after them. By default events are kept
+// in a MemoryAuditLog.
+//
+// Each member of a cluster keeps its own audit log of the calls it served, so
+// a call forwarded to another member is in the logs of both.
+func WithAuditLog(log AuditLog) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.audit.log = log
+	}
+}
+
+// MemoryAuditLog keeps events in memory.
+type MemoryAuditLog struct {
+	mu     sync.Mutex
+	events []*v1.AuditEvent
+}
+
+// Append implements AuditLog.
+func (l *MemoryAuditLog) Append(event *v1.AuditEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+	return nil
+}
+
+// Replay implements AuditLog.
+func (l *MemoryAuditLog) Replay(fn func(*v1.AuditEvent) error) error {
+	l.mu.Lock()
+	events := append([]*v1.AuditEvent(nil), l.events...)
+	l.mu.Unlock()
+
+	for _, event := range events {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FileAuditLog appends events to a file, one JSON object per line, so it can
+// be shipped to a log pipeline or inspected with ordinary text tools.
+type FileAuditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileAuditLog opens the audit log at path, creating it if it doesn't
+// exist.
+func OpenFileAuditLog(path string) (*FileAuditLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &FileAuditLog{file: file}, nil
+}
+
+// Append implements AuditLog.
+func (l *FileAuditLog) Append(event *v1.AuditEvent) error {
+	line, err := protojson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.file.Write(append(line, '\n'))
+	return err
+}
+
+// Replay implements AuditLog.
+func (l *FileAuditLog) Replay(fn func(*v1.AuditEvent) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(l.file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		event := &v1.AuditEvent{}
+		if err := protojson.Unmarshal(scanner.Bytes(), event); err != nil {
+			return fmt.Errorf("audit log line %d: %w", line, err)
+		}
+		if err := fn(event); err != nil {
+			return fmt.Errorf("audit log line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Close closes the audit log file.
+func (l *FileAuditLog) Close() error {
+	return l.file.Close()
+}
+
+// auditor writes a handler's audit log.
+type auditor struct {
+	mu       sync.Mutex // Keeps events in sequence order
+	log      AuditLog
+	sequence int64 // Sequence number of the last event written
+	now      func() time.Time
+}
+
+// recover finds the sequence number of the last event already in the log.
+func (a *auditor) recover() error {
+	return a.log.Replay(func(event *v1.AuditEvent) error {
+		a.sequence = event.GetSequence()
+		return nil
+	})
+}
+
+// write appends an event for a call to the log.
+func (a *auditor) write(ctx context.Context, procedure string, changes *auditChanges, err error) error {
+	event := &v1.AuditEvent{
+		Actor:   &v1.AuditActor{},
+		Action:  procedure[strings.LastIndex(procedure, "/")+1:],
+		Code:    "ok",
+		Changes: changes.list(),
+	}
+	if identity := IdentityFrom(ctx); identity != nil {
+		event.Actor.Subject = identity.Subject
+		event.Actor.Roles = identity.Roles
+	}
+	if err != nil {
+		event.Code = connect.CodeOf(err).String()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	event.Sequence = a.sequence + 1
+	event.OccurredAt = timestamppb.New(a.now())
+	if err := a.log.Append(event); err != nil {
+		return err
+	}
+	a.sequence = event.GetSequence()
+	return nil
+}
+
+// WrapUnary implements connect.Interceptor by writing an event for every
+// audited call once it has been served.
+func (a *auditor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if unauditedProcedures[procedure] {
+			return next(ctx, req)
+		}
+		changes := &auditChanges{}
+		res, err := next(context.WithValue(ctx, auditChangesKey{}, changes), req)
+		a.finish(ctx, procedure, changes, err)
+		return res, err
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor. The handler makes no
+// streaming calls, so there's nothing to audit.
+func (a *auditor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor by writing an event for
+// every audited streaming call once the handler returns.
+func (a *auditor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		procedure := conn.Spec().Procedure
+		if unauditedProcedures[procedure] {
+			return next(ctx, conn)
+		}
+		changes := &auditChanges{}
+		err := next(context.WithValue(ctx, auditChangesKey{}, changes), conn)
+		a.finish(ctx, procedure, changes, err)
+		return err
+	}
+}
+
+// finish writes the event for a call. The call's changes are already in the
+// ledger by now, so a failed write doesn't fail the call; it is recorded on
+// the call's span instead.
+func (a *auditor) finish(ctx context.Context, procedure string, changes *auditChanges, err error) {
+	if err := a.write(ctx, procedure, changes, err); err != nil {
+		span := trace.SpanFromContext(ctx)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to write audit event: %v", err))
+	}
+}
+
+type auditChangesKey struct{}
+
+// auditChanges collects the bookings changed while serving an audited call,
+// with how they were before the call's first change to them and after its
+// last.
+type auditChanges struct {
+	changes []*v1.AuditChange
+}
+
+// noteBefore records the bookings an event is about to change, unless the
+// call already changed them. ctx is the context of the call recording event.
+// The caller must hold h.mu.
+func (h *MyTrainTicketingServiceHandler) noteBefore(ctx context.Context, event *v1.LedgerEvent) {
+	c, ok := ctx.Value(auditChangesKey{}).(*auditChanges)
+	if !ok {
+		return
+	}
+	for _, id := range eventBookingIDs(event) {
+		if c.find(id) != nil {
+			continue
+		}
+		change := &v1.AuditChange{BookingId: id}
+		if b, ok := h.bookings[id]; ok {
+			change.Before = b.receipt()
+		}
+		c.changes = append(c.changes, change)
+	}
+}
+
+// noteAfter records the bookings an event changed, once it has been applied.
+// The caller must hold h.mu.
+func (h *MyTrainTicketingServiceHandler) noteAfter(ctx context.Context, event *v1.LedgerEvent) {
+	c, ok := ctx.Value(auditChangesKey{}).(*auditChanges)
+	if !ok {
+		return
+	}
+	for _, id := range eventBookingIDs(event) {
+		change := c.find(id)
+		change.After = nil
+		if b, ok := h.bookings[id]; ok {
+			change.After = b.receipt()
+		}
+	}
+}
+
+func (c *auditChanges) find(id string) *v1.AuditChange {
+	for _, change := range c.changes {
+		if change.GetBookingId() == id {
+			return change
+		}
+	}
+	return nil
+}
+
+// list returns the changes, leaving out seats held and released again without
+// being bought.
+func (c *auditChanges) list() []*v1.AuditChange {
+	var changes []*v1.AuditChange
+	for _, change := range c.changes {
+		if change.GetBefore() != nil || change.GetAfter() != nil {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// ListAuditEvents implements the ListAuditEvents method of
+// TrainTicketingServiceHandler. Events are listed newest first. Only admins
+// may read the audit log.
+func (h *MyTrainTicketingServiceHandler) ListAuditEvents(ctx context.Context, req *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Msg.GetPageSize() < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("page size must not be negative"))
+	}
+	before := int64(-1)
+	if token := req.Msg.GetPageToken(); token != "" {
+		var err error
+		if before, err = strconv.ParseInt(token, 10, 64); err != nil || before < 1 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errInvalidPageToken)
+		}
+	}
+
+	var events []*v1.AuditEvent
+	err := h.audit.log.Replay(func(event *v1.AuditEvent) error {
+		if (before < 0 || event.GetSequence() < before) && auditEventMatches(req.Msg, event) {
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to read audit log: %w", err))
+	}
+
+	response := &v1.ListAuditEventsResponse{}
+	for i := len(events) - 1; i >= 0; i-- {
+		if size := int(min(req.Msg.GetPageSize(), MAX_ADMIN_PAGE_SIZE)); size > 0 && len(response.Events) == size {
+			response.NextPageToken = strconv.FormatInt(response.Events[size-1].GetSequence(), 10)
+			break
+		}
+		response.Events = append(response.Events, proto.Clone(events[i]).(*v1.AuditEvent))
+	}
+	return connect.NewResponse(response), nil
+}
+
+// auditEventMatches reports whether an audit event passes the filters of req.
+func auditEventMatches(req *v1.ListAuditEventsRequest, event *v1.AuditEvent) bool {
+	if subject := req.GetSubject(); subject != "" && event.GetActor().GetSubject() != subject {
+		return false
+	}
+	if action := req.GetAction(); action != "" && event.GetAction() != action {
+		return false
+	}
+	if after := req.GetOccurredAfter(); after != nil && event.GetOccurredAt().AsTime().Before(after.AsTime()) {
+		return false
+	}
+	if before := req.GetOccurredBefore(); before != nil && !event.GetOccurredAt().AsTime().Before(before.AsTime()) {
+		return false
+	}
+	if id := req.GetBookingId(); id != "" {
+		for _, change := range event.GetChanges() {
+			if change.GetBookingId() == id {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
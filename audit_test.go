@@ -0,0 +1,209 @@
+package ticketing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+var testJWTKey = []byte("test-signing-key")
+
+// signJWT returns a token for subject with roles, signed with testJWTKey.
+func signJWT(t *testing.T, subject string, roles ...string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   subject,
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString(testJWTKey)
+	if err != nil {
+		t.Fatalf("SignedString failed: %v", err)
+	}
+	return token
+}
+
+func listAuditEvents(t *testing.T, client ticketingv1.TrainTicketingServiceClient, req *v1.ListAuditEventsRequest) []*v1.AuditEvent {
+	t.Helper()
+	res, err := client.ListAuditEvents(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("ListAuditEvents failed: %v", err)
+	}
+	return res.Msg.GetEvents()
+}
+
+func auditActions(events []*v1.AuditEvent) string {
+	var actions []string
+	for _, event := range events {
+		actions = append(actions, event.GetAction()+":"+event.GetCode())
+	}
+	return strings.Join(actions, " ")
+}
+
+func TestAuditLog(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)))
+	agent := newClient(srv, signJWT(t, "agent-7", "agent"))
+	admin := newClient(srv, signJWT(t, "alice", "admin", "auditor"))
+
+	jane := purchaseTicket(t, agent, "Jane", "Roe", "jane@example.com")
+	if _, err := admin.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "Jane"},
+		NewSeatNumber: 15,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	if _, err := admin.RemoveUser(context.Background(), connect.NewRequest(&v1.RemoveUserRequest{User: &v1.User{FirstName: "Nobody"}})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected RemoveUser to fail with NotFound, got %v", err)
+	}
+	if _, err := admin.RemoveUser(context.Background(), connect.NewRequest(&v1.RemoveUserRequest{User: &v1.User{FirstName: "Jane"}})); err != nil {
+		t.Fatalf("RemoveUser failed: %v", err)
+	}
+	// Passengers' own lookups aren't audited
+	takenSeats(t, agent, "")
+
+	events := listAuditEvents(t, admin, &v1.ListAuditEventsRequest{})
+	if got, want := auditActions(events), "RemoveUser:ok RemoveUser:not_found ModifySeat:ok PurchaseTicket:ok"; got != want {
+		t.Fatalf("expected events %s, got %s", want, got)
+	}
+	for i, event := range events {
+		if event.GetSequence() != int64(len(events)-i) || event.GetOccurredAt() == nil {
+			t.Fatalf("expected event %d to be numbered %d and timestamped, got %v", i, len(events)-i, event)
+		}
+	}
+
+	// The agent bought a seat, which the admin then moved and freed
+	purchase := events[3]
+	if purchase.GetActor().GetSubject() != "agent-7" || strings.Join(purchase.GetActor().GetRoles(), ",") != "agent" {
+		t.Fatalf("expected the purchase to be made by agent-7, got %v", purchase.GetActor())
+	}
+	if len(purchase.GetChanges()) != 1 || purchase.GetChanges()[0].GetBookingId() != jane || purchase.GetChanges()[0].GetBefore() != nil ||
+		purchase.GetChanges()[0].GetAfter().GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		t.Fatalf("expected the purchase to create a confirmed booking, got %v", purchase.GetChanges())
+	}
+	modify := events[2]
+	if modify.GetActor().GetSubject() != "alice" || strings.Join(modify.GetActor().GetRoles(), ",") != "admin,auditor" {
+		t.Fatalf("expected the seat to be moved by alice, got %v", modify.GetActor())
+	}
+	change := modify.GetChanges()[0]
+	if before, after := change.GetBefore().GetTicket().GetSeat().GetSeatNumber(), change.GetAfter().GetTicket().GetSeat().GetSeatNumber(); before != 1 || after != 15 {
+		t.Fatalf("expected the seat to move from 1 to 15, got %d to %d", before, after)
+	}
+	if len(events[1].GetChanges()) != 0 {
+		t.Fatalf("expected the failed call to change nothing, got %v", events[1].GetChanges())
+	}
+	removal := events[0].GetChanges()
+	if len(removal) != 1 || removal[0].GetBefore().GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED ||
+		removal[0].GetAfter().GetStatus() != v1.BookingStatus_BOOKING_STATUS_CANCELLED {
+		t.Fatalf("expected the removal to cancel the booking, got %v", removal)
+	}
+
+	// Listing the audit log is audited too
+	events = listAuditEvents(t, admin, &v1.ListAuditEventsRequest{Subject: "alice", Action: "ListAuditEvents"})
+	if len(events) != 1 || events[0].GetSequence() != 5 {
+		t.Fatalf("expected the first listing to be audited, got %s", auditActions(events))
+	}
+
+	// Only admins may read it
+	if _, err := agent.ListAuditEvents(context.Background(), connect.NewRequest(&v1.ListAuditEventsRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied listing the audit log without the admin role, got %v", err)
+	}
+}
+
+func TestListAuditEventsFilters(t *testing.T) {
+	client := newTestClient(t)
+	jane := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	if _, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: jane})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	viewAdminSeats(t, client)
+
+	// The demo token doesn't say who sent it
+	events := listAuditEvents(t, client, &v1.ListAuditEventsRequest{BookingId: jane})
+	if got, want := auditActions(events), "CancelBooking:ok PurchaseTicket:ok"; got != want {
+		t.Fatalf("expected events %s, got %s", want, got)
+	}
+	if events[0].GetActor().GetSubject() != "" {
+		t.Fatalf("expected no subject, got %q", events[0].GetActor().GetSubject())
+	}
+	if got := auditActions(listAuditEvents(t, client, &v1.ListAuditEventsRequest{Action: "PurchaseTicket"})); got != "PurchaseTicket:ok PurchaseTicket:ok" {
+		t.Fatalf("expected both purchases, got %s", got)
+	}
+	if events := listAuditEvents(t, client, &v1.ListAuditEventsRequest{OccurredAfter: events[0].GetOccurredAt()}); len(events) < 3 {
+		t.Fatalf("expected the cancellation and the calls after it, got %s", auditActions(events))
+	}
+
+	// Pages stay in place as more calls are audited, including the listing
+	// itself
+	var pages []string
+	req := &v1.ListAuditEventsRequest{PageSize: 2}
+	for {
+		res, err := client.ListAuditEvents(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			t.Fatalf("ListAuditEvents failed: %v", err)
+		}
+		pages = append(pages, auditActions(res.Msg.GetEvents()))
+		if req.PageToken = res.Msg.GetNextPageToken(); req.PageToken == "" {
+			break
+		}
+	}
+	want := []string{"ListAuditEvents:ok ListAuditEvents:ok", "ListAuditEvents:ok ViewAdminDetails:ok", "CancelBooking:ok PurchaseTicket:ok", "PurchaseTicket:ok"}
+	if strings.Join(pages, " | ") != strings.Join(want, " | ") {
+		t.Fatalf("expected pages %q, got %q", want, pages)
+	}
+
+	_, err := client.ListAuditEvents(context.Background(), connect.NewRequest(&v1.ListAuditEventsRequest{PageToken: "bogus"}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for a bad page token, got %v", err)
+	}
+}
+
+func TestFileAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	open := func() ticketingv1.TrainTicketingServiceClient {
+		log, err := server.OpenFileAuditLog(path)
+		if err != nil {
+			t.Fatalf("OpenFileAuditLog failed: %v", err)
+		}
+		t.Cleanup(func() { log.Close() })
+		return newTestClient(t, server.WithAuditLog(log))
+	}
+
+	purchaseTicket(t, open(), "Jane", "Roe", "jane@example.com")
+
+	// Events are numbered on from the ones already in the log
+	client := open()
+	viewAdminSeats(t, client)
+	events := listAuditEvents(t, client, &v1.ListAuditEventsRequest{})
+	if got := auditActions(events); got != "ViewAdminDetails:ok PurchaseTicket:ok" {
+		t.Fatalf("expected both events, got %s", got)
+	}
+	if events[0].GetSequence() != 2 {
+		t.Fatalf("expected the second event to be numbered 2, got %d", events[0].GetSequence())
+	}
+
+	// Each event is a line of JSON
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	event := &v1.AuditEvent{}
+	if err := protojson.Unmarshal([]byte(lines[0]), event); err != nil || event.GetAction() != "PurchaseTicket" {
+		t.Fatalf("expected the purchase on the first line, got %s (%v)", lines[0], err)
+	}
+}
@@ -0,0 +1,132 @@
+package ticketing
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	connect "connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DEMO_AUTH_TOKEN is the bearer token accepted when no Authenticator is
+// configured. It is for demos only.
+const DEMO_AUTH_TOKEN = "auth_token"
+
+// ADMIN_ROLE is the role callers need to make admin calls, such as reviewing
+// bookings held for review.
+const ADMIN_ROLE = "admin"
+
+// CONDUCTOR_ROLE is the role of train staff, who may look passengers up
+// without being admins.
+const CONDUCTOR_ROLE = "conductor"
+
+// Identity is who sent a request, as vouched for by an Authenticator.
+type Identity struct {
+	Subject string
+	Roles   []string
+}
+
+// Authenticator checks the credentials sent with a request before it is
+// served, returning an error to turn the request away. It returns who sent the
+// request when the credentials say so, and nil otherwise.
+type Authenticator func(r *http.Request) (*Identity, error)
+
+type identityKey struct{}
+
+// IdentityFrom returns who sent the request being served with ctx, or nil when
+// the Authenticator didn't say.
+func IdentityFrom(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+// requireAdmin turns away callers who were identified without ADMIN_ROLE.
+func requireAdmin(ctx context.Context) error {
+	return requireRole(ctx, ADMIN_ROLE)
+}
+
+// requireAccount turns away callers who were identified as someone other than
+// account, the subject that made a booking, unless they have ADMIN_ROLE.
+func requireAccount(ctx context.Context, account string) error {
+	identity := IdentityFrom(ctx)
+	if identity == nil || (account != "" && identity.Subject == account) || slices.Contains(identity.Roles, ADMIN_ROLE) {
+		return nil
+	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s didn't make this booking and doesn't have the %s role", identity.Subject, ADMIN_ROLE))
+}
+
+// requireRole turns away callers who were identified without any of roles.
+// Callers the Authenticator doesn't identify, such as those sharing a bearer
+// token, can't be told apart, so they are all let through.
+func requireRole(ctx context.Context, roles ...string) error {
+	identity := IdentityFrom(ctx)
+	if identity == nil || slices.ContainsFunc(identity.Roles, func(role string) bool { return slices.Contains(roles, role) }) {
+		return nil
+	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s doesn't have the %s role", identity.Subject, strings.Join(roles, " or ")))
+}
+
+// WithAuthenticator sets how requests are authenticated. By default only
+// requests carrying DEMO_AUTH_TOKEN are served.
+func WithAuthenticator(authenticate Authenticator) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.authenticate = authenticate
+	}
+}
+
+// BearerToken returns an Authenticator that accepts requests whose
+// Authorization header carries token. It doesn't know who sent them.
+func BearerToken(token string) Authenticator {
+	return func(r *http.Request) (*Identity, error) {
+		sent, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			return nil, errors.New("no JWT token provided")
+		}
+		if subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			return nil, errors.New("invalid token")
+		}
+		return nil, nil
+	}
+}
+
+// jwtClaims are the claims read from the JWTs accepted by JWT.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+// JWT returns an Authenticator that accepts requests whose Authorization
+// header carries a JWT signed with key using HS256. Tokens past their exp or
+// before their nbf are turned away. Callers are identified by the token's sub
+// claim, and their roles are taken from its roles claim, a list of strings.
+func JWT(key []byte) Authenticator {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	keyFunc := func(*jwt.Token) (interface{}, error) { return key, nil }
+	return func(r *http.Request) (*Identity, error) {
+		sent, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			return nil, errors.New("no JWT token provided")
+		}
+		claims := &jwtClaims{}
+		if _, err := parser.ParseWithClaims(sent, claims, keyFunc); err != nil {
+			return nil, fmt.Errorf("invalid token: %w", err)
+		}
+		if claims.Subject == "" {
+			return nil, errors.New("invalid token: no subject")
+		}
+		return &Identity{Subject: claims.Subject, Roles: claims.Roles}, nil
+	}
+}
+
+// NoAuthentication returns an Authenticator that accepts every request, for
+// servers that sit behind a proxy doing the authentication.
+func NoAuthentication() Authenticator {
+	return func(*http.Request) (*Identity, error) {
+		return nil, nil
+	}
+}
@@ -0,0 +1,99 @@
+package ticketing_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+func viewAdminDetailsCode(client ticketingv1.TrainTicketingServiceClient) connect.Code {
+	_, err := client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+	if err == nil {
+		return 0
+	}
+	return connect.CodeOf(err)
+}
+
+func TestAuthenticators(t *testing.T) {
+	// By default only the demo token is accepted
+	_, srv := startServer(t)
+	if code := viewAdminDetailsCode(newClient(srv, server.DEMO_AUTH_TOKEN)); code != 0 {
+		t.Fatalf("expected the demo token to be accepted, got %v", code)
+	}
+	if code := viewAdminDetailsCode(newClient(srv, "")); code != connect.CodeUnauthenticated {
+		t.Fatalf("expected a request without a token to be turned away, got %v", code)
+	}
+
+	_, srv = startServer(t, server.WithAuthenticator(server.BearerToken("s3cret")))
+	if code := viewAdminDetailsCode(newClient(srv, "s3cret")); code != 0 {
+		t.Fatalf("expected the configured token to be accepted, got %v", code)
+	}
+	if code := viewAdminDetailsCode(newClient(srv, server.DEMO_AUTH_TOKEN)); code != connect.CodeUnauthenticated {
+		t.Fatalf("expected the demo token to be turned away, got %v", code)
+	}
+
+	_, srv = startServer(t, server.WithAuthenticator(server.NoAuthentication()))
+	if code := viewAdminDetailsCode(newClient(srv, "")); code != 0 {
+		t.Fatalf("expected every request to be accepted, got %v", code)
+	}
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)))
+	if code := viewAdminDetailsCode(newClient(srv, signJWT(t, "alice", "admin"))); code != 0 {
+		t.Fatalf("expected a signed token to be accepted, got %v", code)
+	}
+
+	sign := func(method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString failed: %v", err)
+		}
+		return token
+	}
+	for name, token := range map[string]string{
+		"demo token":   server.DEMO_AUTH_TOKEN,
+		"wrong key":    sign(jwt.SigningMethodHS256, []byte("other-key"), jwt.MapClaims{"sub": "alice"}),
+		"expired":      sign(jwt.SigningMethodHS256, testJWTKey, jwt.MapClaims{"sub": "alice", "exp": time.Now().Add(-time.Minute).Unix()}),
+		"no subject":   sign(jwt.SigningMethodHS256, testJWTKey, jwt.MapClaims{"roles": []string{"admin"}}),
+		"unsigned":     sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "alice"}),
+		"wrong method": sign(jwt.SigningMethodHS512, testJWTKey, jwt.MapClaims{"sub": "alice"}),
+	} {
+		if code := viewAdminDetailsCode(newClient(srv, token)); code != connect.CodeUnauthenticated {
+			t.Errorf("expected a token with %s to be turned away, got %v", name, code)
+		}
+	}
+}
+
+func TestWithInterceptors(t *testing.T) {
+	var mu sync.Mutex
+	var procedures []string
+	record := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			mu.Lock()
+			procedures = append(procedures, req.Spec().Procedure)
+			mu.Unlock()
+			return next(ctx, req)
+		}
+	})
+	client := newTestClient(t, server.WithInterceptors(record))
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	viewAdminSeats(t, client)
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := ticketingv1.TrainTicketingServicePurchaseTicketProcedure + " " + ticketingv1.TrainTicketingServiceViewAdminDetailsProcedure
+	if got := strings.Join(procedures, " "); got != want {
+		t.Fatalf("expected the interceptor to see %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,480 @@
+package ticketing
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// BOLT_OPEN_TIMEOUT is how long OpenBoltLedger waits for another process to
+// close the database.
+const BOLT_OPEN_TIMEOUT = 5 * time.Second
+
+// BOLT_COMPACT_TX_SIZE is how many bytes CompactBoltLedger copies per
+// transaction.
+const BOLT_COMPACT_TX_SIZE = 64 * 1024 * 1024
+
+var (
+	boltEvents              = []byte("events")                // Sequence number -> LedgerEvent
+	boltBookings            = []byte("bookings")              // Booking ID -> BookingRecord
+	boltUsers               = []byte("users")                 // Email -> User
+	boltDiscountCodes       = []byte("discount_codes")        // Discount code -> number of redemptions
+	boltSeats               = []byte("seats")                 // Departure ID, seat number -> booking ID
+	boltBookingsByEmail     = []byte("bookings_by_email")     // Email, booking ID -> nothing
+	boltBookingsByDeparture = []byte("bookings_by_departure") // Departure ID, booking ID -> nothing
+)
+
+// BoltLedger is a Ledger kept in a single bbolt file for single-node
+// deployments. Besides the events it keeps the current seats, bookings, users
+// and discount code redemptions, updated in the same transaction as each
+// event, with bookings indexed by ID, email and departure. The handler is
+// restored from those tables instead of replaying every event.
+type BoltLedger struct {
+	db *bolt.DB
+}
+
+// OpenBoltLedger opens the ledger at path, creating it if it doesn't exist.
+// Only one process can have the ledger open at a time.
+func OpenBoltLedger(path string) (*BoltLedger, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: BOLT_OPEN_TIMEOUT})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltEvents, boltBookings, boltUsers, boltDiscountCodes, boltSeats, boltBookingsByEmail, boltBookingsByDeparture} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set up ledger: %w", err)
+	}
+	return &BoltLedger{db: db}, nil
+}
+
+// Append implements Ledger. The event and the changes it makes to the stored
+// bookings are written together or not at all.
+func (l *BoltLedger) Append(event *v1.LedgerEvent) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode ledger event: %w", err)
+	}
+	return l.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(boltEvents).Put(boltSequenceKey(event.GetSequence()), data); err != nil {
+			return err
+		}
+		return boltProject(tx, event)
+	})
+}
+
+// Replay implements Ledger.
+func (l *BoltLedger) Replay(fn func(*v1.LedgerEvent) error) error {
+	return l.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltEvents).ForEach(func(key, data []byte) error {
+			event := &v1.LedgerEvent{}
+			if err := proto.Unmarshal(data, event); err != nil {
+				return fmt.Errorf("ledger event %d: %w", binary.BigEndian.Uint64(key), err)
+			}
+			return fn(event)
+		})
+	})
+}
+
+// LoadSnapshot implements Snapshotter by reading the stored bookings, which
+// are always up to date with the last event.
+func (l *BoltLedger) LoadSnapshot() (*v1.Snapshot, error) {
+	var snapshot *v1.Snapshot
+	err := l.db.View(func(tx *bolt.Tx) error {
+		last, _ := tx.Bucket(boltEvents).Cursor().Last()
+		if last == nil {
+			return nil
+		}
+		snapshot = &v1.Snapshot{
+			Sequence:    int64(binary.BigEndian.Uint64(last)),
+			Redemptions: make(map[string]int32),
+		}
+		err := tx.Bucket(boltUsers).ForEach(func(_, data []byte) error {
+			user := &v1.User{}
+			if err := proto.Unmarshal(data, user); err != nil {
+				return err
+			}
+			snapshot.Users = append(snapshot.Users, user)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.Bucket(boltDiscountCodes).ForEach(func(code, count []byte) error {
+			snapshot.Redemptions[string(code)] = int32(binary.BigEndian.Uint64(count))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(boltBookings).ForEach(func(_, data []byte) error {
+			record := &v1.BookingRecord{}
+			if err := proto.Unmarshal(data, record); err != nil {
+				return err
+			}
+			snapshot.Bookings = append(snapshot.Bookings, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bookings: %w", err)
+	}
+	return snapshot, nil
+}
+
+// SaveSnapshot implements Snapshotter. The stored bookings are updated with
+// every event, so there is nothing to save.
+func (l *BoltLedger) SaveSnapshot(*v1.Snapshot) error {
+	return nil
+}
+
+// Booking returns the booking with the given ID, or nil if there is none.
+func (l *BoltLedger) Booking(id string) (*v1.BookingRecord, error) {
+	var record *v1.BookingRecord
+	err := l.db.View(func(tx *bolt.Tx) error {
+		var err error
+		record, err = boltBooking(tx, id)
+		return err
+	})
+	return record, err
+}
+
+// BookingsByEmail returns every booking made for the user with the given
+// email, including cancelled and exchanged ones, ordered by booking ID.
+func (l *BoltLedger) BookingsByEmail(email string) ([]*v1.BookingRecord, error) {
+	return l.bookingsByIndex(boltBookingsByEmail, email)
+}
+
+// BookingsByDeparture returns every booking made on a departure, including
+// cancelled and exchanged ones, ordered by booking ID.
+func (l *BoltLedger) BookingsByDeparture(departureID string) ([]*v1.BookingRecord, error) {
+	return l.bookingsByIndex(boltBookingsByDeparture, departureID)
+}
+
+func (l *BoltLedger) bookingsByIndex(index []byte, value string) ([]*v1.BookingRecord, error) {
+	var records []*v1.BookingRecord
+	err := l.db.View(func(tx *bolt.Tx) error {
+		prefix := boltIndexKey(value, "")
+		c := tx.Bucket(index).Cursor()
+		for key, _ := c.Seek(prefix); key != nil && len(key) >= len(prefix) && string(key[:len(prefix)]) == string(prefix); key, _ = c.Next() {
+			record, err := boltBooking(tx, string(key[len(prefix):]))
+			if err != nil {
+				return err
+			}
+			if record != nil {
+				records = append(records, record)
+			}
+		}
+		return nil
+	})
+	return records, err
+}
+
+// Backup writes a consistent copy of the ledger to w while it stays in use.
+func (l *BoltLedger) Backup(w io.Writer) (int64, error) {
+	var n int64
+	err := l.db.View(func(tx *bolt.Tx) error {
+		var err error
+		n, err = tx.WriteTo(w)
+		return err
+	})
+	return n, err
+}
+
+// Close closes the ledger file.
+func (l *BoltLedger) Close() error {
+	return l.db.Close()
+}
+
+// CompactBoltLedger copies the ledger at src to a new file at dst, leaving out
+// the free pages bbolt keeps after deletes. The ledger must not be open. dst
+// must not exist; if compacting fails, the file it was creating is removed.
+func CompactBoltLedger(src, dst string) error {
+	from, err := bolt.Open(src, 0o600, &bolt.Options{Timeout: BOLT_OPEN_TIMEOUT, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open ledger: %w", err)
+	}
+	defer from.Close()
+
+	// Claim dst before bbolt opens it, so an existing file is never touched
+	file, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%s already exists", dst)
+		}
+		return fmt.Errorf("failed to create compacted ledger: %w", err)
+	}
+	file.Close()
+	to, err := bolt.Open(dst, 0o600, &bolt.Options{Timeout: BOLT_OPEN_TIMEOUT})
+	if err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("failed to create compacted ledger: %w", err)
+	}
+	if err := bolt.Compact(to, from, BOLT_COMPACT_TX_SIZE); err != nil {
+		to.Close()
+		os.Remove(dst)
+		return fmt.Errorf("failed to compact ledger: %w", err)
+	}
+	return to.Close()
+}
+
+// boltProject applies an event to the stored seats, bookings, users and
+// discount codes, the same way the handler applies it to its own state.
+func boltProject(tx *bolt.Tx, event *v1.LedgerEvent) error {
+	at := event.GetOccurredAt()
+
+	switch e := event.GetEvent().(type) {
+	case *v1.LedgerEvent_SeatHeld:
+		ticket := proto.Clone(e.SeatHeld.GetTicket()).(*v1.Ticket)
+		if err := boltTakeSeat(tx, ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber(), e.SeatHeld.GetBookingId()); err != nil {
+			return err
+		}
+		ticket.Seat.User = ticket.GetUser()
+		status := v1.BookingStatus_BOOKING_STATUS_HELD
+		if e.SeatHeld.GetReviewReason() != "" {
+			status = v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW
+		}
+		return boltPutBooking(tx, &v1.BookingRecord{
+			Id:                 e.SeatHeld.GetBookingId(),
+			Ticket:             ticket,
+			Status:             status,
+			PurchasedAt:        at,
+			PaymentMethod:      e.SeatHeld.GetPaymentMethod(),
+			Version:            1,
+			Account:            e.SeatHeld.GetAccount(),
+			ClientIp:           e.SeatHeld.GetClientIp(),
+			PaymentFingerprint: e.SeatHeld.GetPaymentFingerprint(),
+			ReviewReason:       e.SeatHeld.GetReviewReason(),
+		})
+
+	case *v1.LedgerEvent_HoldReleased:
+		record, err := boltEventBooking(tx, e.HoldReleased.GetBookingId())
+		if err != nil {
+			return err
+		}
+		if err := boltFreeSeat(tx, record); err != nil {
+			return err
+		}
+		return boltDeleteBooking(tx, record)
+
+	case *v1.LedgerEvent_BookingConfirmed:
+		record, err := boltEventBooking(tx, e.BookingConfirmed.GetBookingId())
+		if err != nil {
+			return err
+		}
+		record.Status = v1.BookingStatus_BOOKING_STATUS_CONFIRMED
+		record.Payments = e.BookingConfirmed.GetPayments()
+		if err := boltPut(tx, boltUsers, record.GetTicket().GetUser().GetEmail(), record.GetTicket().GetUser()); err != nil {
+			return err
+		}
+		return boltPutBooking(tx, record)
+
+	case *v1.LedgerEvent_DiscountRedeemed:
+		codes := tx.Bucket(boltDiscountCodes)
+		code := []byte(e.DiscountRedeemed.GetDiscountCode())
+		var count uint64
+		if data := codes.Get(code); data != nil {
+			count = binary.BigEndian.Uint64(data)
+		}
+		return codes.Put(code, binary.BigEndian.AppendUint64(nil, count+1))
+
+	case *v1.LedgerEvent_SeatModified:
+		record, err := boltEventBooking(tx, e.SeatModified.GetBookingId())
+		if err != nil {
+			return err
+		}
+		if err := boltFreeSeat(tx, record); err != nil {
+			return err
+		}
+		if err := boltTakeSeat(tx, record.GetTicket().GetDepartureId(), e.SeatModified.GetSeatNumber(), record.GetId()); err != nil {
+			return err
+		}
+		record.Ticket.Seat.SeatNumber = e.SeatModified.GetSeatNumber()
+		record.Version++
+		return boltPutBooking(tx, record)
+
+	case *v1.LedgerEvent_BookingCancelled:
+		record, err := boltEventBooking(tx, e.BookingCancelled.GetBookingId())
+		if err != nil {
+			return err
+		}
+		if err := boltFreeSeat(tx, record); err != nil {
+			return err
+		}
+		record.Status = v1.BookingStatus_BOOKING_STATUS_CANCELLED
+		record.Version++
+		record.CancelledAt = at
+		record.RefundAmount = e.BookingCancelled.GetRefundAmount()
+		record.Payments = e.BookingCancelled.GetPayments()
+		return boltPutBooking(tx, record)
+
+	case *v1.LedgerEvent_BookingRejected:
+		record, err := boltEventBooking(tx, e.BookingRejected.GetBookingId())
+		if err != nil {
+			return err
+		}
+		if err := boltFreeSeat(tx, record); err != nil {
+			return err
+		}
+		record.Status = v1.BookingStatus_BOOKING_STATUS_REJECTED
+		record.Version++
+		return boltPutBooking(tx, record)
+
+	case *v1.LedgerEvent_UserRemoved:
+		return tx.Bucket(boltUsers).Delete([]byte(e.UserRemoved.GetEmail()))
+
+	case *v1.LedgerEvent_BookingExchanged:
+		old, err := boltEventBooking(tx, e.BookingExchanged.GetBookingId())
+		if err != nil {
+			return err
+		}
+		ticket := proto.Clone(e.BookingExchanged.GetTicket()).(*v1.Ticket)
+		if err := boltFreeSeat(tx, old); err != nil {
+			return err
+		}
+		if err := boltTakeSeat(tx, ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber(), e.BookingExchanged.GetNewBookingId()); err != nil {
+			return err
+		}
+		ticket.Seat.User = old.GetTicket().GetUser()
+		err = boltPutBooking(tx, &v1.BookingRecord{
+			Id:                 e.BookingExchanged.GetNewBookingId(),
+			Ticket:             ticket,
+			Status:             v1.BookingStatus_BOOKING_STATUS_CONFIRMED,
+			PurchasedAt:        at,
+			PreviousBookingIds: append(append([]string(nil), old.GetPreviousBookingIds()...), old.GetId()),
+			PaymentMethod:      old.GetPaymentMethod(),
+			Payments:           e.BookingExchanged.GetPayments(),
+			Version:            1,
+		})
+		if err != nil {
+			return err
+		}
+		old.Status = v1.BookingStatus_BOOKING_STATUS_EXCHANGED
+		old.Version++
+		old.Payments = nil
+		return boltPutBooking(tx, old)
+
+	case *v1.LedgerEvent_BookingsImported:
+		for _, imported := range e.BookingsImported.GetBookings() {
+			ticket := proto.Clone(imported.GetTicket()).(*v1.Ticket)
+			if err := boltTakeSeat(tx, ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber(), imported.GetBookingId()); err != nil {
+				return err
+			}
+			ticket.Seat.User = ticket.GetUser()
+			if err := boltPut(tx, boltUsers, ticket.GetUser().GetEmail(), ticket.GetUser()); err != nil {
+				return err
+			}
+			err := boltPutBooking(tx, &v1.BookingRecord{
+				Id:          imported.GetBookingId(),
+				Ticket:      ticket,
+				Status:      v1.BookingStatus_BOOKING_STATUS_CONFIRMED,
+				PurchasedAt: at,
+				Version:     1,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown ledger event %d", event.GetSequence())
+	}
+}
+
+// boltTakeSeat gives a free seat to a booking.
+func boltTakeSeat(tx *bolt.Tx, departureID string, seatNumber int32, bookingID string) error {
+	seats := tx.Bucket(boltSeats)
+	key := boltSeatKey(departureID, seatNumber)
+	if holder := seats.Get(key); holder != nil {
+		return fmt.Errorf("seat %d on departure %q is held by booking %s", seatNumber, departureID, holder)
+	}
+	return seats.Put(key, []byte(bookingID))
+}
+
+// boltFreeSeat frees the seat held by a booking.
+func boltFreeSeat(tx *bolt.Tx, record *v1.BookingRecord) error {
+	return tx.Bucket(boltSeats).Delete(boltSeatKey(record.GetTicket().GetDepartureId(), record.GetTicket().GetSeat().GetSeatNumber()))
+}
+
+// boltPutBooking stores a booking and its index entries.
+func boltPutBooking(tx *bolt.Tx, record *v1.BookingRecord) error {
+	if err := boltPut(tx, boltBookings, record.GetId(), record); err != nil {
+		return err
+	}
+	if err := tx.Bucket(boltBookingsByEmail).Put(boltIndexKey(record.GetTicket().GetUser().GetEmail(), record.GetId()), nil); err != nil {
+		return err
+	}
+	return tx.Bucket(boltBookingsByDeparture).Put(boltIndexKey(record.GetTicket().GetDepartureId(), record.GetId()), nil)
+}
+
+// boltDeleteBooking removes a booking and its index entries.
+func boltDeleteBooking(tx *bolt.Tx, record *v1.BookingRecord) error {
+	if err := tx.Bucket(boltBookings).Delete([]byte(record.GetId())); err != nil {
+		return err
+	}
+	if err := tx.Bucket(boltBookingsByEmail).Delete(boltIndexKey(record.GetTicket().GetUser().GetEmail(), record.GetId())); err != nil {
+		return err
+	}
+	return tx.Bucket(boltBookingsByDeparture).Delete(boltIndexKey(record.GetTicket().GetDepartureId(), record.GetId()))
+}
+
+// boltBooking returns a stored booking, or nil if there is none.
+func boltBooking(tx *bolt.Tx, id string) (*v1.BookingRecord, error) {
+	data := tx.Bucket(boltBookings).Get([]byte(id))
+	if data == nil {
+		return nil, nil
+	}
+	record := &v1.BookingRecord{}
+	if err := proto.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("booking %q: %w", id, err)
+	}
+	return record, nil
+}
+
+// boltEventBooking returns the stored booking an event refers to.
+func boltEventBooking(tx *bolt.Tx, id string) (*v1.BookingRecord, error) {
+	record, err := boltBooking(tx, id)
+	if err == nil && record == nil {
+		err = fmt.Errorf("booking %q not found", id)
+	}
+	return record, err
+}
+
+func boltPut(tx *bolt.Tx, bucket []byte, key string, m proto.Message) error {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucket).Put([]byte(key), data)
+}
+
+// boltSequenceKey encodes a sequence number so keys sort in event order.
+func boltSequenceKey(sequence int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(sequence))
+}
+
+func boltSeatKey(departureID string, seatNumber int32) []byte {
+	return binary.BigEndian.AppendUint32(boltIndexKey(departureID, ""), uint32(seatNumber))
+}
+
+// boltIndexKey joins an indexed value and an ID with a separator that can't
+// appear in either, so all IDs for a value share a prefix.
+func boltIndexKey(value, id string) []byte {
+	return []byte(value + "\x00" + id)
+}
@@ -0,0 +1,156 @@
+package ticketing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// openTestBolt opens the bbolt ledger at path until the test ends.
+func openTestBolt(t *testing.T, path string) *server.BoltLedger {
+	t.Helper()
+	ledger, err := server.OpenBoltLedger(path)
+	if err != nil {
+		t.Fatalf("OpenBoltLedger failed: %v", err)
+	}
+	t.Cleanup(func() { ledger.Close() })
+	return ledger
+}
+
+func TestBoltLedgerIndexesBookings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.db")
+	departures := testDepartures(time.Now().Add(48 * time.Hour))
+	ledger := openTestBolt(t, path)
+	client := newTestClient(t, departures, server.WithLedger(ledger))
+
+	janeID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	johnID := purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	if _, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: johnID})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	exchanged, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   janeID,
+		DepartureId: "evening",
+	}))
+	if err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+	exchangedID := exchanged.Msg.GetReceipt().GetBookingId()
+
+	// Lookups by booking ID see the latest status of each booking
+	john, err := ledger.Booking(johnID)
+	if err != nil || john.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CANCELLED {
+		t.Fatalf("expected John's booking to be cancelled, got %v (%v)", john, err)
+	}
+	if missing, err := ledger.Booking("no-such-booking"); err != nil || missing != nil {
+		t.Fatalf("expected no booking, got %v (%v)", missing, err)
+	}
+
+	// Jane's bookings include the one she exchanged away from
+	bookings, err := ledger.BookingsByEmail("jane@example.com")
+	if err != nil {
+		t.Fatalf("BookingsByEmail failed: %v", err)
+	}
+	if len(bookings) != 2 {
+		t.Fatalf("expected 2 bookings for Jane, got %v", bookings)
+	}
+	for _, b := range bookings {
+		if b.GetId() == exchangedID && b.GetTicket().GetDepartureId() != "evening" {
+			t.Fatalf("expected the exchanged booking on the evening train, got %v", b)
+		}
+	}
+
+	// Only the exchanged booking is on the evening train
+	evening, err := ledger.BookingsByDeparture("evening")
+	if err != nil {
+		t.Fatalf("BookingsByDeparture failed: %v", err)
+	}
+	if len(evening) != 1 || evening[0].GetId() != exchangedID {
+		t.Fatalf("expected only %s on the evening train, got %v", exchangedID, evening)
+	}
+	morning, err := ledger.BookingsByDeparture("morning")
+	if err != nil {
+		t.Fatalf("BookingsByDeparture failed: %v", err)
+	}
+	if len(morning) != 2 {
+		t.Fatalf("expected Jane's and John's original bookings on the morning train, got %v", morning)
+	}
+}
+
+func TestBoltLedgerRestoresFromProjection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.db")
+	departures := testDepartures(time.Now().Add(48 * time.Hour))
+	ledger := openTestBolt(t, path)
+	client := newTestClient(t, departures, server.WithLedger(ledger))
+
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	johnID := purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	purchaseTicket(t, client, "Mary", "Major", "mary@example.com")
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "Jane"},
+		NewSeatNumber: 12,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	if _, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: johnID})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	want := viewAdminSeats(t, client)
+	ledger.Close()
+
+	// A new handler starts from the stored bookings and keeps appending
+	restored := newTestClient(t, departures, server.WithLedger(openTestBolt(t, path)))
+	assertSameSeats(t, want, viewAdminSeats(t, restored))
+	purchaseTicket(t, restored, "Paul", "Poe", "paul@example.com")
+	seats := viewAdminSeats(t, restored)
+	if len(seats) != len(want)+1 {
+		t.Fatalf("expected %d seats after another purchase, got %v", len(want)+1, seats)
+	}
+}
+
+func TestBoltLedgerBackupAndCompact(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ledger.db")
+	departures := testDepartures(time.Now().Add(48 * time.Hour))
+	ledger := openTestBolt(t, path)
+	client := newTestClient(t, departures, server.WithLedger(ledger))
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	want := viewAdminSeats(t, client)
+
+	// A backup taken while the ledger is in use opens like the original
+	backupPath := filepath.Join(dir, "backup.db")
+	file, err := os.Create(backupPath)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := ledger.Backup(file); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	file.Close()
+	fromBackup := newTestClient(t, departures, server.WithLedger(openTestBolt(t, backupPath)))
+	assertSameSeats(t, want, viewAdminSeats(t, fromBackup))
+
+	// Compaction needs the ledger closed and won't overwrite an existing file
+	ledger.Close()
+	if err := server.CompactBoltLedger(path, backupPath); err == nil {
+		t.Fatal("expected compaction onto an existing file to fail")
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		t.Fatalf("expected the existing file to be left alone, got %v", err)
+	}
+	compactPath := filepath.Join(dir, "compact.db")
+	if err := server.CompactBoltLedger(path, compactPath); err != nil {
+		t.Fatalf("CompactBoltLedger failed: %v", err)
+	}
+	compacted := newTestClient(t, departures, server.WithLedger(openTestBolt(t, compactPath)))
+	assertSameSeats(t, want, viewAdminSeats(t, compacted))
+}
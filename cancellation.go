@@ -0,0 +1,119 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CancellationRules decide how much of the fare is refunded when a booking is
+// cancelled. Cancelling at least FullRefundBefore ahead of departure refunds
+// the whole fare, cancelling later refunds PartialRefundRate of it, and
+// nothing is refunded once the train has left.
+type CancellationRules struct {
+	FullRefundBefore  time.Duration
+	PartialRefundRate float64
+}
+
+// DefaultCancellationRules give a full refund up to 24 hours before departure
+// and half the fare after that.
+var DefaultCancellationRules = CancellationRules{
+	FullRefundBefore:  24 * time.Hour,
+	PartialRefundRate: 0.5,
+}
+
+// WithCancellationRules overrides DefaultCancellationRules.
+func WithCancellationRules(rules CancellationRules) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.cancellationRules = rules
+	}
+}
+
+// Refund returns the amount refunded for a fare of pricePaid when cancelled at
+// cancelledAt for a train leaving at departure, rounded to the cent.
+func (r CancellationRules) Refund(pricePaid float32, cancelledAt, departure time.Time) float32 {
+	var refund float64
+	switch {
+	case !cancelledAt.Before(departure):
+		refund = 0
+	case departure.Sub(cancelledAt) >= r.FullRefundBefore:
+		refund = float64(pricePaid)
+	default:
+		refund = float64(pricePaid) * r.PartialRefundRate
+	}
+	return float32(math.Round(refund*100) / 100)
+}
+
+// CancelBooking implements the CancelBooking method of TrainTicketingServiceHandler.
+// Only admins and the account that made the booking may cancel it.
+func (h *MyTrainTicketingServiceHandler) CancelBooking(ctx context.Context, req *connect.Request[v1.CancelBookingRequest]) (*connect.Response[v1.CancelBookingResponse], error) {
+	bookingID := req.Msg.GetBookingId()
+	if bookingID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("booking ID is required"))
+	}
+
+	// Lock the booking's departure and the maps
+	bookings, unlock, err := h.lockBookings(ctx, func() ([]*booking, error) {
+		b, ok := h.bookings[bookingID]
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("booking not found"))
+		}
+		return []*booking{b}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	b := bookings[0]
+
+	if err := requireAccount(ctx, b.account); err != nil {
+		return nil, err
+	}
+	if b.status != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("only confirmed bookings can be cancelled"))
+	}
+	if err := b.checkVersion(req.Msg.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+
+	// Work out the refund before the seat is released for resale, and record
+	// the cancellation before paying it out, so a cancellation that can't be
+	// recorded refunds nothing and can safely be retried. The refund is paid
+	// out while holding the departure's lock, but not the maps, so other
+	// trains can still be booked.
+	refund := h.cancellationRules.Refund(b.ticket.GetPricePaid(), h.now(), b.ticket.GetDepartureTime().AsTime())
+	paid := b.payments
+	payments, _ := splitRefund(paid, refund)
+	err = h.record(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_BookingCancelled{BookingCancelled: &v1.BookingCancelled{
+		BookingId:    b.id,
+		RefundAmount: refund,
+		Payments:     payments,
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	h.unlocked(func() {
+		_, err = h.refund(context.WithoutCancel(ctx), paid, refund)
+	})
+	if err != nil {
+		return nil, afterRecording(connect.CodeOf(err), fmt.Errorf("booking was cancelled but couldn't be refunded: %w", err))
+	}
+
+	response := &v1.CancelBookingResponse{
+		CancellationReceipt: &v1.CancellationReceipt{
+			BookingId:    b.id,
+			Ticket:       proto.Clone(b.ticket).(*v1.Ticket),
+			RefundAmount: b.refundAmount,
+			CancelledAt:  timestamppb.New(b.cancelledAt),
+			Version:      b.version,
+		},
+	}
+	return connect.NewResponse(response), nil
+}
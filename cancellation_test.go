@@ -0,0 +1,120 @@
+package ticketing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+func TestCancelBooking(t *testing.T) {
+	departure := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		beforeDepart time.Duration
+		wantRefund   float32
+	}{
+		{name: "full refund before T-24h", beforeDepart: 48 * time.Hour, wantRefund: 20},
+		{name: "partial refund after T-24h", beforeDepart: 2 * time.Hour, wantRefund: 10},
+		{name: "no refund after departure", beforeDepart: -time.Hour, wantRefund: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := departure.Add(-72 * time.Hour)
+			client := newTestClient(t,
+				server.WithDepartureTime(departure),
+				server.WithClock(func() time.Time { return now }),
+			)
+
+			bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+			now = departure.Add(-tt.beforeDepart)
+			response, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID}))
+			if err != nil {
+				t.Fatalf("CancelBooking failed: %v", err)
+			}
+			cancellation := response.Msg.GetCancellationReceipt()
+			if cancellation.GetRefundAmount() != tt.wantRefund {
+				t.Fatalf("expected refund %v, got %v", tt.wantRefund, cancellation.GetRefundAmount())
+			}
+			if !cancellation.GetCancelledAt().AsTime().Equal(now) {
+				t.Fatalf("expected cancellation time %v, got %v", now, cancellation.GetCancelledAt().AsTime())
+			}
+
+			// The seat is free again, so nobody shows up in the admin view
+			admin, err := client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+			if err != nil {
+				t.Fatalf("ViewAdminDetails failed: %v", err)
+			}
+			if len(admin.Msg.GetAdminView().GetSeats()) != 0 {
+				t.Fatalf("expected seat to be released, got %v", admin.Msg.GetAdminView().GetSeats())
+			}
+
+			_, err = client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID}))
+			if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+				t.Fatalf("expected FailedPrecondition cancelling twice, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCancelBookingNotFound(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: "missing"}))
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeNotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestRemoveUserReturnsReceipt(t *testing.T) {
+	client := newTestClient(t)
+
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+	response, err := client.RemoveUser(context.Background(), connect.NewRequest(&v1.RemoveUserRequest{
+		User: &v1.User{FirstName: "Jane"},
+	}))
+	if err != nil {
+		t.Fatalf("RemoveUser failed: %v", err)
+	}
+	receipt := response.Msg.GetReceipt()
+	if receipt.GetBookingId() != bookingID || receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CANCELLED {
+		t.Fatalf("expected cancelled receipt for %s, got %v", bookingID, receipt)
+	}
+}
+
+func TestCancelBookingRefundsOnlyOnceRecorded(t *testing.T) {
+	provider := &server.FakePaymentProvider{}
+	ledger := &failingLedger{fail: func(event *v1.LedgerEvent) bool { return event.GetBookingCancelled() != nil }}
+	client := newTestClient(t, server.WithLedger(ledger), server.WithPaymentProvider(provider))
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+	// Retrying a cancellation that couldn't be recorded refunds the fare once
+	cancel := func() error {
+		_, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID}))
+		return err
+	}
+	if err := cancel(); connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	if payments := provider.Payments(); len(payments) != 1 || payments[0].Refunded != 0 {
+		t.Fatalf("expected nothing to be refunded, got %+v", payments)
+	}
+	ledger.fail = func(*v1.LedgerEvent) bool { return false }
+	if err := cancel(); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if payments := provider.Payments(); payments[0].Refunded != payments[0].Amount {
+		t.Fatalf("expected the fare to be refunded once, got %+v", payments)
+	}
+}
@@ -0,0 +1,379 @@
+package ticketing
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// RING_REPLICAS is the number of points each member gets on the hash ring.
+// More points spread departures more evenly between members.
+const RING_REPLICAS = 128
+
+// FORWARDED_BY_HEADER marks a request one cluster member passed to another, so
+// the receiving member serves it itself instead of forwarding it again.
+const FORWARDED_BY_HEADER = "Ticketing-Forwarded-By"
+
+// CLUSTER_SECRET_HEADER carries the secret cluster members share, which proves
+// a forwarded request came from a member rather than a client.
+const CLUSTER_SECRET_HEADER = "Ticketing-Cluster-Secret"
+
+// peerHeaders are the request headers only cluster members may send. They
+// are dropped from requests without the cluster's secret.
+var peerHeaders = []string{FORWARDED_BY_HEADER, CLIENT_IP_HEADER, CLUSTER_SECRET_HEADER}
+
+// forwardedHeaders are the request headers passed on when forwarding.
+var forwardedHeaders = []string{"Authorization", IDEMPOTENCY_KEY_HEADER}
+
+// Ring assigns keys to members by consistent hashing: adding or removing a
+// member only moves the keys that member gains or loses.
+type Ring struct {
+	points  []uint64
+	members map[uint64]string
+}
+
+// NewRing creates a ring over the given members.
+func NewRing(members ...string) *Ring {
+	r := &Ring{members: make(map[uint64]string)}
+	for _, member := range members {
+		for i := 0; i < RING_REPLICAS; i++ {
+			point := ringHash(member + "#" + strconv.Itoa(i))
+			if _, ok := r.members[point]; ok {
+				continue
+			}
+			r.members[point] = member
+			r.points = append(r.points, point)
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+// Owner returns the member that owns key, or "" if the ring is empty.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	hash := ringHash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= hash })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.members[r.points[i]]
+}
+
+func ringHash(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// cluster is the static set of handlers that share out the departures. Each
+// member is identified by the base URL it serves the API on.
+type cluster struct {
+	self   string
+	peers  []string // Every member but self, in the configured order
+	ring   *Ring
+	http   connect.HTTPClient
+	secret string
+}
+
+// WithCluster shards departures across the given members with a consistent
+// hash ring. self is the base URL this handler is served on and must be one of
+// members. Every member must be configured with the same members, departures
+// and WithClusterSecret.
+//
+// Requests for a departure are forwarded to the member that owns it. Requests
+// that name a booking or user are tried on each member in turn until one finds
+// it, and ViewAdminDetails and ListBookingReviews collect the bookings of every
+// member. Exchanging a ticket onto a departure owned by another member is not
+// supported.
+func WithCluster(self string, members ...string) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		c := &cluster{self: self, ring: NewRing(members...)}
+		for _, member := range members {
+			if member != self {
+				c.peers = append(c.peers, member)
+			}
+		}
+		h.cluster = c
+	}
+}
+
+// WithClusterSecret sets the secret members send with the requests they
+// forward to each other. Requests without it are treated as coming from
+// clients, whatever members they claim to have been forwarded by. It must be
+// set when WithCluster is.
+func WithClusterSecret(secret string) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.clusterSecret = secret
+	}
+}
+
+// WithClusterHTTPClient sets the client used to forward requests to other
+// members. It defaults to http.DefaultClient.
+func WithClusterHTTPClient(client connect.HTTPClient) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.clusterHTTP = client
+	}
+}
+
+// withPeerHeaders drops the headers only cluster members may send from
+// requests that don't carry the cluster's secret, so a client can't skip rate
+// limits or give another address by claiming a member forwarded its request.
+// The secret itself is never passed further in.
+func (h *MyTrainTicketingServiceHandler) withPeerHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices.ContainsFunc(peerHeaders, func(name string) bool { return r.Header.Get(name) != "" }) {
+			trusted := h.cluster != nil && h.cluster.trusts(r.Header)
+			r = r.Clone(r.Context())
+			for _, name := range peerHeaders {
+				if !trusted || name == CLUSTER_SECRET_HEADER {
+					r.Header.Del(name)
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// trusts reports whether header carries the cluster's secret.
+func (c *cluster) trusts(header http.Header) bool {
+	secret := header.Get(CLUSTER_SECRET_HEADER)
+	return secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(c.secret)) == 1
+}
+
+// markForwarded sets the headers that tell the member a request is sent to
+// that this member forwarded it.
+func (c *cluster) markForwarded(header http.Header) {
+	header.Set(FORWARDED_BY_HEADER, c.self)
+	header.Set(CLUSTER_SECRET_HEADER, c.secret)
+}
+
+// owns reports whether this handler serves the departure with the given ID.
+func (h *MyTrainTicketingServiceHandler) owns(departureID string) bool {
+	return h.cluster == nil || h.cluster.ring.Owner(departureID) == h.cluster.self
+}
+
+// Interceptor sends each request to the members that can serve it. Requests
+// already forwarded by another member, which withPeerHeaders has checked, are
+// always served locally.
+func (c *cluster) Interceptor(h *MyTrainTicketingServiceHandler) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Header().Get(FORWARDED_BY_HEADER) != "" {
+				return next(ctx, req)
+			}
+
+			switch msg := req.Any().(type) {
+			case *v1.PurchaseTicketRequest:
+				return c.toOwner(ctx, h, next, req, msg.GetTicket().GetDepartureId())
+			case *v1.ViewSeatMapRequest:
+				return c.toOwner(ctx, h, next, req, msg.GetDepartureId())
+			case *v1.ViewAdminDetailsRequest:
+				return c.gatherAdminDetails(ctx, h, next, req)
+			case *v1.RemoveUserRequest:
+				return c.broadcast(ctx, next, req)
+			case *v1.SearchPassengersRequest:
+				return c.gatherPassengers(ctx, h, next, req)
+			case *v1.ListBookingReviewsRequest:
+				return c.gatherReviews(ctx, next, req)
+			default:
+				return c.findFirst(ctx, next, req)
+			}
+		}
+	}
+}
+
+// toOwner serves req on the member that owns the departure it names, or the
+// default departure when departureID is empty.
+func (c *cluster) toOwner(ctx context.Context, h *MyTrainTicketingServiceHandler, next connect.UnaryFunc, req connect.AnyRequest, departureID string) (connect.AnyResponse, error) {
+	if departureID == "" {
+		departureID = h.defaultDepartureID
+	}
+	if owner := c.ring.Owner(departureID); owner != c.self {
+		return c.forward(ctx, owner, req)
+	}
+	return next(ctx, req)
+}
+
+// findFirst serves req locally, then on each peer in turn, until a member
+// finds what it names.
+func (c *cluster) findFirst(ctx context.Context, next connect.UnaryFunc, req connect.AnyRequest) (connect.AnyResponse, error) {
+	res, err := next(ctx, req)
+	for _, peer := range c.peers {
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			break
+		}
+		res, err = c.forward(ctx, peer, req)
+	}
+	return res, err
+}
+
+// broadcast serves req on every member, as a user may have bookings on
+// departures owned by different members. It succeeds if any member found the
+// user and returns the response with the latest receipt.
+func (c *cluster) broadcast(ctx context.Context, next connect.UnaryFunc, req connect.AnyRequest) (connect.AnyResponse, error) {
+	res, err := next(ctx, req)
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		return nil, err
+	}
+	for _, peer := range c.peers {
+		peerRes, peerErr := c.forward(ctx, peer, req)
+		if connect.CodeOf(peerErr) == connect.CodeNotFound {
+			continue
+		}
+		if peerErr != nil {
+			return nil, peerErr
+		}
+		if err != nil || peerRes.Any().(*v1.RemoveUserResponse).GetReceipt() != nil {
+			res, err = peerRes, nil
+		}
+	}
+	return res, err
+}
+
+// gatherAdminDetails combines the seats taken on every member. Page tokens
+// hold the position in the sort order rather than an offset, so each member
+// returns the page starting after the same position, and the first page_size
+// seats of those pages make up the page of the whole cluster.
+func (c *cluster) gatherAdminDetails(ctx context.Context, h *MyTrainTicketingServiceHandler, next connect.UnaryFunc, req connect.AnyRequest) (connect.AnyResponse, error) {
+	query, err := h.newAdminQuery(req.Any().(*v1.ViewAdminDetailsRequest))
+	if err != nil {
+		return nil, err
+	}
+	res, err := next(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	bookings := res.Any().(*v1.ViewAdminDetailsResponse).GetAdminView().GetBookings()
+	for _, peer := range c.peers {
+		peerRes, err := c.forward(ctx, peer, req)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, peerRes.Any().(*v1.ViewAdminDetailsResponse).GetAdminView().GetBookings()...)
+	}
+
+	view, nextPageToken, err := query.page(bookings)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&v1.ViewAdminDetailsResponse{AdminView: view, NextPageToken: nextPageToken}), nil
+}
+
+// gatherPassengers combines the passengers found on every member. Each member
+// returns its best matches, so the best of those are the best of the cluster.
+func (c *cluster) gatherPassengers(ctx context.Context, h *MyTrainTicketingServiceHandler, next connect.UnaryFunc, req connect.AnyRequest) (connect.AnyResponse, error) {
+	res, err := next(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	matches := res.Any().(*v1.SearchPassengersResponse).GetMatches()
+	for _, peer := range c.peers {
+		peerRes, err := c.forward(ctx, peer, req)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, peerRes.Any().(*v1.SearchPassengersResponse).GetMatches()...)
+	}
+
+	departureOrder := make(map[string]int)
+	for i, info := range h.schedule {
+		departureOrder[info.GetId()] = i
+	}
+	sortPassengerMatches(matches, departureOrder)
+	limit := int(req.Any().(*v1.SearchPassengersRequest).GetLimit())
+	if limit == 0 {
+		limit = DEFAULT_SEARCH_LIMIT
+	}
+	if limit = min(limit, MAX_SEARCH_LIMIT); len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return connect.NewResponse(&v1.SearchPassengersResponse{Matches: matches}), nil
+}
+
+// gatherReviews combines the bookings held for review on every member, oldest
+// first.
+func (c *cluster) gatherReviews(ctx context.Context, next connect.UnaryFunc, req connect.AnyRequest) (connect.AnyResponse, error) {
+	res, err := next(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	reviews := res.Any().(*v1.ListBookingReviewsResponse).GetReviews()
+	for _, peer := range c.peers {
+		peerRes, err := c.forward(ctx, peer, req)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, peerRes.Any().(*v1.ListBookingReviewsResponse).GetReviews()...)
+	}
+
+	sort.SliceStable(reviews, func(i, j int) bool {
+		a, b := reviews[i].GetBooking(), reviews[j].GetBooking()
+		if !a.GetPurchasedAt().AsTime().Equal(b.GetPurchasedAt().AsTime()) {
+			return a.GetPurchasedAt().AsTime().Before(b.GetPurchasedAt().AsTime())
+		}
+		return a.GetBookingId() < b.GetBookingId()
+	})
+	return connect.NewResponse(&v1.ListBookingReviewsResponse{Reviews: reviews}), nil
+}
+
+// forward sends req to member and returns its response.
+func (c *cluster) forward(ctx context.Context, member string, req connect.AnyRequest) (connect.AnyResponse, error) {
+	client := ticketingv1.NewTrainTicketingServiceClient(c.http, member)
+	switch msg := req.Any().(type) {
+	case *v1.PurchaseTicketRequest:
+		return forwardTo(ctx, c, client.PurchaseTicket, req, msg)
+	case *v1.ViewReceiptRequest:
+		return forwardTo(ctx, c, client.ViewReceipt, req, msg)
+	case *v1.ViewAdminDetailsRequest:
+		return forwardTo(ctx, c, client.ViewAdminDetails, req, msg)
+	case *v1.RemoveUserRequest:
+		return forwardTo(ctx, c, client.RemoveUser, req, msg)
+	case *v1.ModifySeatRequest:
+		return forwardTo(ctx, c, client.ModifySeat, req, msg)
+	case *v1.CancelBookingRequest:
+		return forwardTo(ctx, c, client.CancelBooking, req, msg)
+	case *v1.ExchangeTicketRequest:
+		return forwardTo(ctx, c, client.ExchangeTicket, req, msg)
+	case *v1.SearchPassengersRequest:
+		return forwardTo(ctx, c, client.SearchPassengers, req, msg)
+	case *v1.ViewSeatMapRequest:
+		return forwardTo(ctx, c, client.ViewSeatMap, req, msg)
+	case *v1.ListBookingReviewsRequest:
+		return forwardTo(ctx, c, client.ListBookingReviews, req, msg)
+	case *v1.ReviewBookingRequest:
+		return forwardTo(ctx, c, client.ReviewBooking, req, msg)
+	}
+	return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("can't forward %s", req.Spec().Procedure))
+}
+
+// forwardTo calls a member with msg and the caller's credentials.
+func forwardTo[Req, Res any](ctx context.Context, c *cluster, call func(context.Context, *connect.Request[Req]) (*connect.Response[Res], error), from connect.AnyRequest, msg *Req) (connect.AnyResponse, error) {
+	req := connect.NewRequest(msg)
+	for _, name := range forwardedHeaders {
+		if value := from.Header().Get(name); value != "" {
+			req.Header().Set(name, value)
+		}
+	}
+	c.markForwarded(req.Header())
+	req.Header().Set(CLIENT_IP_HEADER, requestIP(from.Peer(), from.Header()))
+	tracePropagator.Inject(ctx, propagation.HeaderCarrier(req.Header()))
+
+	res, err := call(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,254 @@
+package ticketing_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// clusterMember is one handler of a test cluster.
+type clusterMember struct {
+	url    string
+	ledger *server.MemoryLedger
+	client ticketingv1.TrainTicketingServiceClient
+}
+
+// testClusterSecret is the secret test cluster members share.
+const testClusterSecret = "cluster-s3cret"
+
+// newCluster serves n handlers that share out the given departures.
+func newCluster(t *testing.T, n int, departures ...*v1.Departure) []*clusterMember {
+	t.Helper()
+
+	// Every member needs the URLs of all the others before it is created
+	servers := make([]*httptest.Server, n)
+	urls := make([]string, n)
+	for i := range servers {
+		servers[i] = httptest.NewUnstartedServer(nil)
+		urls[i] = "http://" + servers[i].Listener.Addr().String()
+	}
+
+	members := make([]*clusterMember, n)
+	for i, srv := range servers {
+		ledger := &server.MemoryLedger{}
+		handler, err := server.New(
+			server.WithDepartures(departures...),
+			server.WithLedger(ledger),
+			server.WithCluster(urls[i], urls...),
+			server.WithClusterSecret(testClusterSecret),
+		)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		_, srv.Config.Handler = handler.Handler()
+		srv.Start()
+		t.Cleanup(srv.Close)
+		members[i] = &clusterMember{
+			url:    urls[i],
+			ledger: ledger,
+			client: newClient(srv, server.DEMO_AUTH_TOKEN),
+		}
+	}
+	return members
+}
+
+func TestRingSpreadsKeysAndMovesFewOnJoin(t *testing.T) {
+	ring := server.NewRing("a", "b", "c")
+	owners := make(map[string]string)
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("departure-%d", i)
+		owners[key] = ring.Owner(key)
+		counts[owners[key]]++
+	}
+	for member, count := range counts {
+		if count < 600 || count > 1400 {
+			t.Fatalf("member %s owns %d of 3000 keys", member, count)
+		}
+	}
+
+	// A new member only takes keys; none move between the existing members
+	grown := server.NewRing("a", "b", "c", "d")
+	moved := 0
+	for key, owner := range owners {
+		if newOwner := grown.Owner(key); newOwner != owner {
+			if newOwner != "d" {
+				t.Fatalf("key %s moved from %s to %s", key, owner, newOwner)
+			}
+			moved++
+		}
+	}
+	if moved < 450 || moved > 1050 {
+		t.Fatalf("expected about a quarter of the keys to move, %d did", moved)
+	}
+}
+
+func TestClusterRoutesRequestsToOwners(t *testing.T) {
+	morning := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	var departures []*v1.Departure
+	for i := 0; i < 8; i++ {
+		departures = append(departures, &v1.Departure{
+			Id:            fmt.Sprintf("train-%d", i),
+			From:          "London",
+			To:            "Paris",
+			DepartureTime: timestamppb.New(morning.Add(time.Duration(i) * time.Hour)),
+			Fare:          20,
+		})
+	}
+	members := newCluster(t, 3, departures...)
+	urls := make([]string, len(members))
+	for i, m := range members {
+		urls[i] = m.url
+	}
+	ring := server.NewRing(urls...)
+
+	// Buy a ticket on every train through the first member
+	bookingIDs := make(map[string]string)
+	for i, d := range departures {
+		res, err := members[0].client.PurchaseTicket(context.Background(), connect.NewRequest(&v1.PurchaseTicketRequest{
+			Ticket: &v1.Ticket{
+				User:        &v1.User{FirstName: fmt.Sprintf("User%d", i), LastName: "Lee", Email: fmt.Sprintf("user%d@example.com", i)},
+				DepartureId: d.GetId(),
+			},
+		}))
+		if err != nil {
+			t.Fatalf("PurchaseTicket on %s failed: %v", d.GetId(), err)
+		}
+		bookingIDs[d.GetId()] = res.Msg.GetReceipt().GetBookingId()
+	}
+
+	// Each booking was recorded by the owner of its train alone
+	for _, m := range members {
+		for _, event := range m.ledger.Events() {
+			if held := event.GetSeatHeld(); held != nil && ring.Owner(held.GetTicket().GetDepartureId()) != m.url {
+				t.Fatalf("%s recorded a booking on %s, owned by %s", m.url, held.GetTicket().GetDepartureId(), ring.Owner(held.GetTicket().GetDepartureId()))
+			}
+		}
+	}
+
+	// Any member sees every seat
+	for _, m := range members {
+		if seats := viewAdminSeats(t, m.client); len(seats) != len(departures) {
+			t.Fatalf("expected %d seats from %s, got %d", len(departures), m.url, len(seats))
+		}
+	}
+
+	// Bookings and users are found wherever they live
+	last := len(members) - 1
+	if _, err := members[last].client.ViewReceipt(context.Background(), connect.NewRequest(&v1.ViewReceiptRequest{
+		Ticket: &v1.Ticket{User: &v1.User{FirstName: "User0", LastName: "Lee", Email: "user0@example.com"}},
+	})); err != nil {
+		t.Fatalf("ViewReceipt failed: %v", err)
+	}
+	if _, err := members[last].client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingIDs["train-1"]})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if _, err := members[last].client.RemoveUser(context.Background(), connect.NewRequest(&v1.RemoveUserRequest{User: &v1.User{FirstName: "User2"}})); err != nil {
+		t.Fatalf("RemoveUser failed: %v", err)
+	}
+	if _, err := members[0].client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: "no-such-booking"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound for an unknown booking, got %v", err)
+	}
+	if seats := viewAdminSeats(t, members[0].client); len(seats) != len(departures)-2 {
+		t.Fatalf("expected %d seats after a cancellation and a removal, got %d", len(departures)-2, len(seats))
+	}
+
+	// Tickets can only be exchanged between trains served by the same member
+	var sameOwner, otherOwner string
+	for _, d := range departures[4:] {
+		if ring.Owner(d.GetId()) == ring.Owner("train-3") && sameOwner == "" {
+			sameOwner = d.GetId()
+		} else if ring.Owner(d.GetId()) != ring.Owner("train-3") && otherOwner == "" {
+			otherOwner = d.GetId()
+		}
+	}
+	if otherOwner != "" {
+		_, err := members[0].client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+			BookingId:   bookingIDs["train-3"],
+			DepartureId: otherOwner,
+		}))
+		if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+			t.Fatalf("expected FailedPrecondition exchanging onto another member's train, got %v", err)
+		}
+	}
+	if sameOwner != "" {
+		if _, err := members[0].client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+			BookingId:   bookingIDs["train-3"],
+			DepartureId: sameOwner,
+		})); err != nil {
+			t.Fatalf("ExchangeTicket failed: %v", err)
+		}
+	}
+}
+
+func TestClusterTrustsOnlyItsMembers(t *testing.T) {
+	if _, err := server.New(server.WithCluster("http://a", "http://a", "http://b")); err == nil {
+		t.Fatal("expected a cluster without a secret to be refused")
+	}
+
+	departures := []*v1.Departure{}
+	for i := 0; i < 4; i++ {
+		departures = append(departures, &v1.Departure{
+			Id:            fmt.Sprintf("train-%d", i),
+			From:          "London",
+			To:            "Paris",
+			DepartureTime: timestamppb.New(time.Now().Add(48 * time.Hour).Truncate(time.Second)),
+			Fare:          20,
+		})
+	}
+	members := newCluster(t, 2, departures...)
+	ring := server.NewRing(members[0].url, members[1].url)
+	var elsewhere string
+	for _, d := range departures {
+		if ring.Owner(d.GetId()) != members[0].url {
+			elsewhere = d.GetId()
+		}
+	}
+	if elsewhere == "" {
+		t.Skip("the first member owns every departure")
+	}
+
+	for _, tc := range []struct {
+		name   string
+		secret string
+		want   connect.Code
+	}{
+		// A client claiming a member forwarded its call is still forwarded
+		{"no secret", "", 0},
+		{"wrong secret", "guess", 0},
+		// A member's call is served where it lands, which can't serve it
+		{"member", testClusterSecret, connect.CodeUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := []connect.ClientOption{
+				withHeader("Authorization", "Bearer "+server.DEMO_AUTH_TOKEN),
+				withHeader(server.FORWARDED_BY_HEADER, members[1].url),
+			}
+			if tc.secret != "" {
+				opts = append(opts, withHeader(server.CLUSTER_SECRET_HEADER, tc.secret))
+			}
+			client := ticketingv1.NewTrainTicketingServiceClient(http.DefaultClient, members[0].url, opts...)
+			_, err := client.PurchaseTicket(context.Background(), connect.NewRequest(&v1.PurchaseTicketRequest{
+				Ticket: &v1.Ticket{
+					User:        &v1.User{FirstName: "Jane", LastName: "Roe", Email: strings.ReplaceAll(tc.name, " ", "-") + "@example.com"},
+					DepartureId: elsewhere,
+				},
+			}))
+			if connect.CodeOf(err) != tc.want && !(tc.want == 0 && err == nil) {
+				t.Fatalf("expected code %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	server "github.com/parandor/ticketing"
+)
+
+// config is everything the server can be configured with.
+type config struct {
+	listen          string
+	tlsCert         string
+	tlsKey          string
+	h2c             bool
+	auth            string // demo, token, jwt or none
+	authToken       string // The bearer token, or the key JWTs are signed with
+	store           string // memory, file, wal, bolt or postgres
+	storePath       string // File or directory of the store, or the postgres connection string
+	shutdownTimeout time.Duration
+	metricsPath     string // Empty when metrics aren't served
+	auditLog        string // Empty when the audit log is kept in memory
+	rateLimits      string // off, or the limits parsed by parseRateLimits
+	rateLimitKey    string // subject, ip or api-key
+	scalpingRules   string // off, or the rules parsed by parseScalpingRules
+}
+
+func defaultConfig() config {
+	return config{
+		listen:          ":8080",
+		h2c:             true,
+		auth:            "demo",
+		store:           "memory",
+		shutdownTimeout: 30 * time.Second,
+		metricsPath:     "/metrics",
+		rateLimits:      formatRateLimits(server.DefaultRateLimits),
+		rateLimitKey:    "subject",
+		scalpingRules:   formatScalpingRules(server.DefaultScalpingRules),
+	}
+}
+
+// setting is a configuration value. It is read from the config file under its
+// name, from the environment as TICKETING_ followed by its name in upper case
+// with dashes as underscores, and from the flag of the same name, with each
+// overriding the one before.
+type setting struct {
+	name    string
+	usage   string
+	boolean bool // Given as -name rather than -name=value on the command line
+	value   func(c *config) string
+	set     func(c *config, value string) error
+}
+
+var settings = []setting{
+	{"listen", "`address` to listen on", false,
+		func(c *config) string { return c.listen },
+		func(c *config, v string) error { c.listen = v; return nil }},
+	{"tls-cert", "certificate `file` to serve TLS with; requires tls-key", false,
+		func(c *config) string { return c.tlsCert },
+		func(c *config, v string) error { c.tlsCert = v; return nil }},
+	{"tls-key", "private key `file` of tls-cert", false,
+		func(c *config) string { return c.tlsKey },
+		func(c *config, v string) error { c.tlsKey = v; return nil }},
+	{"h2c", "serve HTTP/2 without TLS, for gRPC clients", true,
+		func(c *config) string { return strconv.FormatBool(c.h2c) },
+		func(c *config, v string) (err error) { c.h2c, err = strconv.ParseBool(v); return err }},
+	{"auth", "how requests are authenticated: demo, token, jwt or none", false,
+		func(c *config) string { return c.auth },
+		func(c *config, v string) error { c.auth = v; return nil }},
+	{"auth-token", "bearer `token` accepted when auth is token, or the HS256 key JWTs are signed with when auth is jwt", false,
+		func(c *config) string { return c.authToken },
+		func(c *config, v string) error { c.authToken = v; return nil }},
+	{"store", "where bookings are kept: memory, file, wal, bolt or postgres", false,
+		func(c *config) string { return c.store },
+		func(c *config, v string) error { c.store = v; return nil }},
+	{"store-path", "file or directory of the store, or the postgres connection `string`", false,
+		func(c *config) string { return c.storePath },
+		func(c *config, v string) error { c.storePath = v; return nil }},
+	{"shutdown-timeout", "how long to wait for requests in flight when shutting down", false,
+		func(c *config) string { return c.shutdownTimeout.String() },
+		func(c *config, v string) (err error) { c.shutdownTimeout, err = time.ParseDuration(v); return err }},
+	{"metrics-path", "`path` Prometheus metrics are served on; empty to not serve them", false,
+		func(c *config) string { return c.metricsPath },
+		func(c *config, v string) error { c.metricsPath = v; return nil }},
+	{"audit-log", "`file` the audit log is appended to as JSON lines; kept in memory when empty", false,
+		func(c *config) string { return c.auditLog },
+		func(c *config, v string) error { c.auditLog = v; return nil }},
+	{"rate-limits", "calls a second and burst each client is allowed, as `method=rate:burst,...` with default for the other methods; off to not limit them", false,
+		func(c *config) string { return c.rateLimits },
+		func(c *config, v string) error { c.rateLimits = v; return nil }},
+	{"rate-limit-key", "what clients are told apart by for rate-limits: subject, ip or api-key (the X-Api-Key header)", false,
+		func(c *config) string { return c.rateLimitKey },
+		func(c *config, v string) error { c.rateLimitKey = v; return nil }},
+	{"scalping-rules", "bookings on a departure allowed per account and email, and made per payment method and IP before purchases are held for review, as `account=n,email=n,payment=n,ip=n` with the rules left out turned off; off to turn them all off", false,
+		func(c *config) string { return c.scalpingRules },
+		func(c *config, v string) error { c.scalpingRules = v; return nil }},
+}
+
+// envName returns the environment variable of a setting.
+func envName(name string) string {
+	return "TICKETING_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// loadConfig builds the configuration from the defaults, the config file
+// named by -config or $TICKETING_CONFIG, the environment and the flags in
+// args, in that order.
+func loadConfig(args []string) (config, error) {
+	c := defaultConfig()
+	flags := flag.NewFlagSet("ticketing-server", flag.ExitOnError)
+	configFile := flags.String("config", os.Getenv("TICKETING_CONFIG"), "JSON config `file`")
+	for _, s := range settings {
+		flags.Var(&flagValue{value: s.value(&c), boolean: s.boolean}, s.name, fmt.Sprintf("%s ($%s)", s.usage, envName(s.name)))
+	}
+	flags.Parse(args)
+	if flags.NArg() > 0 {
+		return c, fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	if *configFile != "" {
+		if err := c.loadFile(*configFile); err != nil {
+			return c, err
+		}
+	}
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(envName(s.name)); ok {
+			if err := s.set(&c, value); err != nil {
+				return c, fmt.Errorf("$%s: %w", envName(s.name), err)
+			}
+		}
+	}
+	var err error
+	flags.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.name == f.Name && err == nil {
+				if setErr := s.set(&c, f.Value.String()); setErr != nil {
+					err = fmt.Errorf("-%s: %w", s.name, setErr)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return c, err
+	}
+	return c, c.validate()
+}
+
+// flagValue keeps a setting given on the command line until the config file
+// and environment have been applied.
+type flagValue struct {
+	value   string
+	boolean bool
+}
+
+func (f *flagValue) String() string     { return f.value }
+func (f *flagValue) Set(v string) error { f.value = v; return nil }
+func (f *flagValue) IsBoolFlag() bool   { return f.boolean }
+
+// loadFile applies the settings in a JSON config file. Values may be strings
+// or, where that makes sense, booleans and numbers.
+func (c *config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for name, raw := range values {
+		var s *setting
+		for i := range settings {
+			if settings[i].name == name {
+				s = &settings[i]
+			}
+		}
+		if s == nil {
+			return fmt.Errorf("%s: unknown setting %q", path, name)
+		}
+		value := string(raw)
+		var str string
+		if json.Unmarshal(raw, &str) == nil {
+			value = str
+		}
+		if err := s.set(c, value); err != nil {
+			return fmt.Errorf("%s: %s: %w", path, name, err)
+		}
+	}
+	return nil
+}
+
+func (c *config) validate() error {
+	if (c.tlsCert == "") != (c.tlsKey == "") {
+		return errors.New("tls-cert and tls-key must be set together")
+	}
+	switch c.auth {
+	case "demo", "none":
+	case "token", "jwt":
+		if c.authToken == "" {
+			return fmt.Errorf("auth-token must be set when auth is %s", c.auth)
+		}
+	default:
+		return fmt.Errorf("unknown auth mode %q", c.auth)
+	}
+	switch c.store {
+	case "memory":
+	case "file", "wal", "bolt", "postgres":
+		if c.storePath == "" {
+			return fmt.Errorf("store-path must be set for the %s store", c.store)
+		}
+	default:
+		return fmt.Errorf("unknown store %q", c.store)
+	}
+	if c.shutdownTimeout < 0 {
+		return errors.New("shutdown-timeout must not be negative")
+	}
+	if c.metricsPath != "" && !strings.HasPrefix(c.metricsPath, "/") {
+		return errors.New("metrics-path must start with /")
+	}
+	if c.rateLimits != "off" {
+		if _, err := parseRateLimits(c.rateLimits); err != nil {
+			return fmt.Errorf("rate-limits: %w", err)
+		}
+	}
+	switch c.rateLimitKey {
+	case "subject", "ip", "api-key":
+	default:
+		return fmt.Errorf("unknown rate-limit-key %q", c.rateLimitKey)
+	}
+	if c.scalpingRules != "off" {
+		if _, err := parseScalpingRules(c.scalpingRules); err != nil {
+			return fmt.Errorf("scalping-rules: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseRateLimits reads limits written as method=rate:burst pairs separated by
+// commas, such as default=20:40,PurchaseTicket=1:5. Methods left out are held
+// to the default limit, which is that of DefaultRateLimits when it isn't given.
+func parseRateLimits(value string) (server.RateLimits, error) {
+	limits := server.RateLimits{Default: server.DefaultRateLimits.Default, Methods: make(map[string]server.RateLimit)}
+	for _, entry := range strings.Split(value, ",") {
+		method, limit, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		rateText, burstText, ok2 := strings.Cut(limit, ":")
+		if !ok || !ok2 || method == "" {
+			return limits, fmt.Errorf("%q is not method=rate:burst", entry)
+		}
+		rate, err := strconv.ParseFloat(rateText, 64)
+		if err != nil || rate < 0 {
+			return limits, fmt.Errorf("invalid rate %q for %s", rateText, method)
+		}
+		burst, err := strconv.Atoi(burstText)
+		if err != nil || burst < 0 {
+			return limits, fmt.Errorf("invalid burst %q for %s", burstText, method)
+		}
+		if method == "default" {
+			limits.Default = server.RateLimit{Rate: rate, Burst: burst}
+		} else {
+			limits.Methods[method] = server.RateLimit{Rate: rate, Burst: burst}
+		}
+	}
+	return limits, nil
+}
+
+// formatRateLimits writes limits the way parseRateLimits reads them.
+func formatRateLimits(limits server.RateLimits) string {
+	format := func(method string, limit server.RateLimit) string {
+		return fmt.Sprintf("%s=%s:%d", method, strconv.FormatFloat(limit.Rate, 'f', -1, 64), limit.Burst)
+	}
+	entries := []string{format("default", limits.Default)}
+	var methods []string
+	for method := range limits.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		entries = append(entries, format(method, limits.Methods[method]))
+	}
+	return strings.Join(entries, ",")
+}
+
+// parseScalpingRules reads rules written as name=n pairs separated by commas,
+// such as account=6,email=6,payment=4,ip=10. Rules left out are turned off.
+func parseScalpingRules(value string) (server.ScalpingRules, error) {
+	var rules server.ScalpingRules
+	fields := map[string]*int{
+		"account": &rules.MaxBookingsPerAccount,
+		"email":   &rules.MaxBookingsPerEmail,
+		"payment": &rules.ReviewBookingsPerPayment,
+		"ip":      &rules.ReviewBookingsPerIP,
+	}
+	for _, entry := range strings.Split(value, ",") {
+		name, text, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		field, known := fields[name]
+		if !ok || !known {
+			return rules, fmt.Errorf("%q is not account, email, payment or ip=n", entry)
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil || n < 0 {
+			return rules, fmt.Errorf("invalid number %q for %s", text, name)
+		}
+		*field = n
+	}
+	return rules, nil
+}
+
+// formatScalpingRules writes rules the way parseScalpingRules reads them.
+func formatScalpingRules(rules server.ScalpingRules) string {
+	return fmt.Sprintf("account=%d,email=%d,payment=%d,ip=%d",
+		rules.MaxBookingsPerAccount, rules.MaxBookingsPerEmail, rules.ReviewBookingsPerPayment, rules.ReviewBookingsPerIP)
+}
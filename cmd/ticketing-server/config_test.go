@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// unsetEnv clears every variable the config is read from for the rest of the
+// test.
+func unsetEnv(t *testing.T) {
+	t.Helper()
+	names := []string{"TICKETING_CONFIG"}
+	for _, s := range settings {
+		names = append(names, envName(s.name))
+	}
+	for _, name := range names {
+		// Setenv restores the variable once the test ends
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+// configArgs writes file, when it isn't empty, to a config file named by
+// -config, or by $TICKETING_CONFIG when fileEnv is set, sets the environment
+// in env, and returns the arguments to load the config with.
+func configArgs(t *testing.T, file string, fileEnv bool, env map[string]string, args []string) []string {
+	t.Helper()
+	unsetEnv(t)
+	if file != "" {
+		path := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if fileEnv {
+			t.Setenv("TICKETING_CONFIG", path)
+		} else {
+			args = append([]string{"-config", path}, args...)
+		}
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+	return args
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		file    string // Contents of the config file, if any
+		fileEnv bool
+		env     map[string]string
+		args    []string
+		check   func(c config) bool
+	}{
+		{
+			name:  "defaults",
+			check: func(c config) bool { return c.listen == ":8080" && c.h2c && c.auth == "demo" && c.store == "memory" },
+		},
+		{
+			name:  "file over defaults",
+			file:  `{"listen": ":9000", "h2c": false, "shutdown-timeout": "5s"}`,
+			check: func(c config) bool { return c.listen == ":9000" && !c.h2c && c.shutdownTimeout == 5*time.Second },
+		},
+		{
+			name:  "environment over file",
+			file:  `{"listen": ":9000", "store": "bolt", "store-path": "file.db"}`,
+			env:   map[string]string{"TICKETING_LISTEN": ":9100", "TICKETING_STORE_PATH": "env.db"},
+			check: func(c config) bool { return c.listen == ":9100" && c.store == "bolt" && c.storePath == "env.db" },
+		},
+		{
+			name:  "flags over environment and file",
+			file:  `{"listen": ":9000", "rate-limit-key": "ip"}`,
+			env:   map[string]string{"TICKETING_LISTEN": ":9100", "TICKETING_H2C": "false"},
+			args:  []string{"-listen", ":9200", "-h2c"},
+			check: func(c config) bool { return c.listen == ":9200" && c.h2c && c.rateLimitKey == "ip" },
+		},
+		{
+			name:  "flags left unset keep the environment",
+			env:   map[string]string{"TICKETING_AUTH": "token", "TICKETING_AUTH_TOKEN": "s3cret"},
+			args:  []string{"-metrics-path", ""},
+			check: func(c config) bool { return c.auth == "token" && c.authToken == "s3cret" && c.metricsPath == "" },
+		},
+		{
+			name:    "config file named in the environment",
+			file:    `{"scalping-rules": "off"}`,
+			fileEnv: true,
+			check:   func(c config) bool { return c.scalpingRules == "off" },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			args := configArgs(t, tc.file, tc.fileEnv, tc.env, tc.args)
+			c, err := loadConfig(args)
+			if err != nil {
+				t.Fatalf("loadConfig failed: %v", err)
+			}
+			if !tc.check(c) {
+				t.Fatalf("unexpected config %+v", c)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		file string
+		env  map[string]string
+		args []string
+		want string // In the error
+	}{
+		{name: "unknown file setting", file: `{"colour": "blue"}`, want: `unknown setting "colour"`},
+		{name: "bad file value", file: `{"h2c": "sometimes"}`, want: "h2c"},
+		{name: "bad environment value", env: map[string]string{"TICKETING_SHUTDOWN_TIMEOUT": "soon"}, want: "$TICKETING_SHUTDOWN_TIMEOUT"},
+		{name: "bad flag value", args: []string{"-shutdown-timeout", "soon"}, want: "-shutdown-timeout"},
+		{name: "arguments", args: []string{"serve"}, want: "unexpected arguments"},
+		{name: "invalid once layered", file: `{"store": "bolt"}`, want: "store-path must be set"},
+		{name: "fixed by a later layer", file: `{"auth": "jwt"}`, args: []string{"-auth", "none"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			args := configArgs(t, tc.file, false, tc.env, tc.args)
+			_, err := loadConfig(args)
+			switch {
+			case tc.want == "" && err != nil:
+				t.Fatalf("loadConfig failed: %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Fatalf("expected an error mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	connect "connectrpc.com/connect"
+)
+
+// requestLogger is a connect.Interceptor that logs every RPC with its outcome
+// and how long it took.
+type requestLogger struct{}
+
+func (requestLogger) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		logRPC(req.Peer().Addr, req.Spec().Procedure, start, err)
+		return res, err
+	}
+}
+
+func (requestLogger) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (requestLogger) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		err := next(ctx, conn)
+		logRPC(conn.Peer().Addr, conn.Spec().Procedure, start, err)
+		return err
+	}
+}
+
+func logRPC(peer, procedure string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = connect.CodeOf(err).String() + ": " + err.Error()
+	}
+	log.Printf("%s %s %s %s", peer, procedure, time.Since(start).Round(time.Microsecond), outcome)
+}
@@ -0,0 +1,231 @@
+// Command ticketing-server serves the train ticketing service to Connect, gRPC
+// and gRPC-Web clients, logging every request to the console. The
+// grpc.health.v1 health service and gRPC server reflection are served
+// alongside it, and Prometheus metrics on /metrics.
+//
+// Usage:
+//
+//	ticketing-server [-config file] [flags]
+//
+// Every flag can also be set in the JSON config file, keyed by the flag's
+// name, or through the environment; run with -h for the list. On SIGINT or
+// SIGTERM the server stops taking new requests and waits up to
+// shutdown-timeout for the ones in flight before exiting.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	server "github.com/parandor/ticketing"
+)
+
+// READ_HEADER_TIMEOUT bounds how long a client may take to send request
+// headers.
+const READ_HEADER_TIMEOUT = 10 * time.Second
+
+func main() {
+	cfg, err := loadConfig(os.Args[1:])
+	if err != nil {
+		log.Fatalf("ticketing-server: %v", err)
+	}
+	if err := run(cfg); err != nil {
+		log.Fatalf("ticketing-server: %v", err)
+	}
+}
+
+// run serves until a signal asks the server to stop.
+func run(cfg config) error {
+	ledger, closeLedger, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeLedger()
+
+	opts := []server.Option{server.WithInterceptors(requestLogger{})}
+	registry := prometheus.NewRegistry()
+	if cfg.metricsPath != "" {
+		registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+		opts = append(opts, server.WithMetrics(registry))
+	}
+	if ledger != nil {
+		opts = append(opts, server.WithLedger(ledger))
+	}
+	switch cfg.auth {
+	case "token":
+		opts = append(opts, server.WithAuthenticator(server.BearerToken(cfg.authToken)))
+	case "jwt":
+		opts = append(opts, server.WithAuthenticator(server.JWT([]byte(cfg.authToken))))
+	case "none":
+		opts = append(opts, server.WithAuthenticator(server.NoAuthentication()))
+	}
+	if cfg.rateLimits != "off" {
+		limits, _ := parseRateLimits(cfg.rateLimits) // Checked by validate
+		key := server.LimitBySubject()
+		switch cfg.rateLimitKey {
+		case "ip":
+			key = server.LimitByClientIP()
+		case "api-key":
+			key = server.LimitByAPIKey("")
+		}
+		opts = append(opts, server.WithRateLimits(limits, key))
+	}
+	if cfg.scalpingRules != "off" {
+		rules, _ := parseScalpingRules(cfg.scalpingRules) // Checked by validate
+		opts = append(opts, server.WithScalpingRules(rules))
+	}
+	if cfg.auditLog != "" {
+		auditLog, err := server.OpenFileAuditLog(cfg.auditLog)
+		if err != nil {
+			return err
+		}
+		defer auditLog.Close()
+		opts = append(opts, server.WithAuditLog(auditLog))
+	}
+	handler, err := server.New(opts...)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	handler.Mount(mux)
+	if cfg.metricsPath != "" {
+		mux.Handle(cfg.metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	}
+
+	// Count requests in flight so shutdown can wait for them. Shutdown alone
+	// doesn't wait for requests on HTTP/2 cleartext connections, as h2c takes
+	// those connections over from the server
+	requests := &inflight{}
+	var root http.Handler = requests.track(mux)
+	h2Server := &http2.Server{}
+	if cfg.h2c && cfg.tlsCert == "" {
+		root = h2c.NewHandler(root, h2Server)
+	}
+	srv := &http.Server{
+		Handler:           root,
+		ReadHeaderTimeout: READ_HEADER_TIMEOUT,
+	}
+	if err := http2.ConfigureServer(srv, h2Server); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", cfg.listen)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	served := make(chan error, 1)
+	go func() {
+		if cfg.tlsCert != "" {
+			served <- srv.ServeTLS(listener, cfg.tlsCert, cfg.tlsKey)
+		} else {
+			served <- srv.Serve(listener)
+		}
+	}()
+	log.Printf("serving on %s (tls: %t, h2c: %t, auth: %s, store: %s)",
+		listener.Addr(), cfg.tlsCert != "", cfg.h2c && cfg.tlsCert == "", cfg.auth, cfg.store)
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	// A second signal stops the server straight away. Health checks report
+	// the server as not serving from now on
+	stop()
+	handler.Drain()
+	log.Printf("shutting down, waiting up to %s for requests in flight", cfg.shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+	defer cancel()
+	err = srv.Shutdown(shutdownCtx)
+	if err == nil {
+		err = requests.wait(shutdownCtx)
+	}
+	if err != nil {
+		srv.Close()
+		return fmt.Errorf("%d requests still in flight after %s", requests.active.Load(), cfg.shutdownTimeout)
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	log.Print("stopped")
+	return nil
+}
+
+// openStore opens the ledger bookings are kept in, and returns it with a
+// function that closes it. The ledger is nil for the memory store, which the
+// handler uses by default.
+func openStore(cfg config) (server.Ledger, func(), error) {
+	switch cfg.store {
+	case "file":
+		ledger, err := server.OpenFileLedger(cfg.storePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ledger, func() { ledger.Close() }, nil
+	case "wal":
+		ledger, err := server.OpenWAL(cfg.storePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ledger, func() { ledger.Close() }, nil
+	case "bolt":
+		ledger, err := server.OpenBoltLedger(cfg.storePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ledger, func() { ledger.Close() }, nil
+	case "postgres":
+		ledger, err := server.OpenPostgresLedger(context.Background(), cfg.storePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ledger, ledger.Close, nil
+	}
+	return nil, func() {}, nil
+}
+
+// inflight counts the requests being served.
+type inflight struct {
+	active atomic.Int64
+}
+
+func (f *inflight) track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.active.Add(1)
+		defer f.active.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// wait returns once no request is in flight, or with ctx's error when it is
+// done first.
+func (f *inflight) wait(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for f.active.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
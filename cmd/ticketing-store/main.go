@@ -0,0 +1,87 @@
+// Command ticketing-store maintains the bbolt file used by a single-node
+// deployment. The server must be stopped first, as only one process can have
+// the file open at a time.
+//
+// Usage:
+//
+//	ticketing-store backup <ledger> <backup>
+//	ticketing-store compact <ledger> <compacted>
+package main
+
+import (
+	"fmt"
+	"os"
+
+	server "github.com/parandor/ticketing"
+)
+
+func main() {
+	if len(os.Args) != 4 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "backup":
+		err = backup(os.Args[2], os.Args[3])
+	case "compact":
+		err = compact(os.Args[2], os.Args[3])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ticketing-store:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: ticketing-store backup <ledger> <backup>")
+	fmt.Fprintln(os.Stderr, "       ticketing-store compact <ledger> <compacted>")
+	os.Exit(2)
+}
+
+// backup copies the ledger at src to dst, which must not exist yet.
+func backup(src, dst string) error {
+	ledger, err := server.OpenBoltLedger(src)
+	if err != nil {
+		return err
+	}
+	defer ledger.Close()
+
+	file, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	n, err := ledger.Backup(file)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	fmt.Printf("backed up %s to %s (%d bytes)\n", src, dst, n)
+	return nil
+}
+
+// compact writes a compacted copy of the ledger at src to dst and reports how
+// much smaller it is.
+func compact(src, dst string) error {
+	before, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err := server.CompactBoltLedger(src, dst); err != nil {
+		return err
+	}
+	after, err := os.Stat(dst)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("compacted %s to %s (%d -> %d bytes)\n", src, dst, before.Size(), after.Size())
+	return nil
+}
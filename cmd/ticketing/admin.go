@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// runAdmin runs an admin subcommand, list, audit, reviews or review.
+func runAdmin(c *cli, args []string) error {
+	if len(args) > 0 && args[0] == "list" {
+		return runAdminList(c, args[1:])
+	}
+	if len(args) > 0 && args[0] == "audit" {
+		return runAdminAudit(c, args[1:])
+	}
+	if len(args) > 0 && args[0] == "reviews" {
+		return runAdminReviews(c, args[1:])
+	}
+	if len(args) > 0 && args[0] == "review" {
+		return runAdminReview(c, args[1:])
+	}
+	return fmt.Errorf("usage: ticketing admin list|audit|reviews|review [flags]")
+}
+
+// runAdminList lists the booked seats, fetching every page unless -page-size
+// is set.
+func runAdminList(c *cli, args []string) error {
+	flags := flag.NewFlagSet("admin list", flag.ExitOnError)
+	departure := flags.String("departure", "", "only seats on departure `ID`")
+	section := flags.String("section", "", "only seats in `section` A or B")
+	email := flags.String("email", "", "only passengers whose email contains `text`")
+	discount := flags.String("discount", "", "only bookings that used discount `code`")
+	after := flags.String("after", "", "only bookings purchased at or after `time` (RFC 3339)")
+	before := flags.String("before", "", "only bookings purchased before `time` (RFC 3339)")
+	sortOrder := flags.String("sort", "seat", "order of the seats: seat, purchased, purchased-desc, name or email")
+	pageSize := flags.Int("page-size", 0, "show one page of `n` seats")
+	pageToken := flags.String("page-token", "", "show the page starting at `token`")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	orders := map[string]v1.AdminSortOrder{
+		"seat":           v1.AdminSortOrder_ADMIN_SORT_ORDER_UNSPECIFIED,
+		"purchased":      v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT,
+		"purchased-desc": v1.AdminSortOrder_ADMIN_SORT_ORDER_PURCHASED_AT_DESC,
+		"name":           v1.AdminSortOrder_ADMIN_SORT_ORDER_NAME,
+		"email":          v1.AdminSortOrder_ADMIN_SORT_ORDER_EMAIL,
+	}
+	order, ok := orders[strings.ToLower(*sortOrder)]
+	if !ok {
+		return fmt.Errorf("unknown sort order %q", *sortOrder)
+	}
+	req := &v1.ViewAdminDetailsRequest{
+		DepartureId:   *departure,
+		EmailContains: *email,
+		DiscountCode:  *discount,
+		SortOrder:     order,
+		PageSize:      int32(*pageSize),
+		PageToken:     *pageToken,
+	}
+	sectionType, err := parseSection(*section)
+	if err != nil {
+		return err
+	}
+	if sectionType != v1.Section_SECTION_TYPE_UNSPECIFIED {
+		req.Section = &v1.Section{SectionType: sectionType}
+	}
+	if req.PurchasedAfter, err = parseTime(*after); err != nil {
+		return err
+	}
+	if req.PurchasedBefore, err = parseTime(*before); err != nil {
+		return err
+	}
+
+	// Gather every page into one response, unless a page was asked for
+	all := &v1.ViewAdminDetailsResponse{AdminView: &v1.AdminView{}}
+	for {
+		res, err := c.client.ViewAdminDetails(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+		view := res.Msg.GetAdminView()
+		all.AdminView.Users = append(all.AdminView.Users, view.GetUsers()...)
+		all.AdminView.Seats = append(all.AdminView.Seats, view.GetSeats()...)
+		all.AdminView.Bookings = append(all.AdminView.Bookings, view.GetBookings()...)
+		all.NextPageToken = res.Msg.GetNextPageToken()
+		if *pageSize != 0 || all.NextPageToken == "" {
+			break
+		}
+		req.PageToken = all.NextPageToken
+	}
+
+	return c.print(all, func(w io.Writer) {
+		receiptTable(w, all.GetAdminView().GetBookings()...)
+		if all.GetNextPageToken() != "" {
+			fmt.Fprintf(w, "\nnext page: -page-token %s\n", all.GetNextPageToken())
+		}
+	})
+}
+
+// runAdminAudit lists the audit log, newest first, fetching every page unless
+// -page-size is set.
+func runAdminAudit(c *cli, args []string) error {
+	flags := flag.NewFlagSet("admin audit", flag.ExitOnError)
+	subject := flags.String("subject", "", "only calls made by `subject`")
+	action := flags.String("action", "", "only calls of `method`, such as ModifySeat")
+	booking := flags.String("booking", "", "only calls that changed booking `ID`")
+	after := flags.String("after", "", "only calls made at or after `time` (RFC 3339)")
+	before := flags.String("before", "", "only calls made before `time` (RFC 3339)")
+	pageSize := flags.Int("page-size", 0, "show one page of `n` events")
+	pageToken := flags.String("page-token", "", "show the page starting at `token`")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	req := &v1.ListAuditEventsRequest{
+		Subject:   *subject,
+		Action:    *action,
+		BookingId: *booking,
+		PageSize:  int32(*pageSize),
+		PageToken: *pageToken,
+	}
+	var err error
+	if req.OccurredAfter, err = parseTime(*after); err != nil {
+		return err
+	}
+	if req.OccurredBefore, err = parseTime(*before); err != nil {
+		return err
+	}
+
+	all := &v1.ListAuditEventsResponse{}
+	for {
+		res, err := c.client.ListAuditEvents(context.Background(), connect.NewRequest(req))
+		if err != nil {
+			return err
+		}
+		all.Events = append(all.Events, res.Msg.GetEvents()...)
+		all.NextPageToken = res.Msg.GetNextPageToken()
+		if *pageSize != 0 || all.NextPageToken == "" {
+			break
+		}
+		req.PageToken = all.NextPageToken
+	}
+
+	return c.print(all, func(w io.Writer) {
+		fmt.Fprintln(w, "SEQUENCE\tTIME\tSUBJECT\tROLES\tACTION\tCODE\tCHANGES")
+		for _, event := range all.GetEvents() {
+			var changes []string
+			for _, change := range event.GetChanges() {
+				changes = append(changes, fmt.Sprintf("%s: %s -> %s", change.GetBookingId(), bookingState(change.GetBefore()), bookingState(change.GetAfter())))
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				event.GetSequence(),
+				formatTime(event.GetOccurredAt()),
+				event.GetActor().GetSubject(),
+				strings.Join(event.GetActor().GetRoles(), ","),
+				event.GetAction(),
+				event.GetCode(),
+				strings.Join(changes, "; "))
+		}
+		if all.GetNextPageToken() != "" {
+			fmt.Fprintf(w, "\nnext page: -page-token %s\n", all.GetNextPageToken())
+		}
+	})
+}
+
+// bookingState sums up a booking in an audit event as its status and seat.
+func bookingState(receipt *v1.Receipt) string {
+	if receipt == nil {
+		return "none"
+	}
+	return statusName(receipt.GetStatus()) + " " + seatName(receipt.GetTicket().GetSeat().GetSeatNumber())
+}
+
+// runAdminReviews lists the bookings held for review, oldest first.
+func runAdminReviews(c *cli, args []string) error {
+	flags := flag.NewFlagSet("admin reviews", flag.ExitOnError)
+	departure := flags.String("departure", "", "only bookings on departure `ID`")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	res, err := c.client.ListBookingReviews(context.Background(), connect.NewRequest(&v1.ListBookingReviewsRequest{DepartureId: *departure}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) {
+		fmt.Fprintln(w, "BOOKING\tDEPARTURE\tSEAT\tEMAIL\tACCOUNT\tCLIENT IP\tPAYMENT\tREASON")
+		for _, review := range res.Msg.GetReviews() {
+			ticket := review.GetBooking().GetTicket()
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				review.GetBooking().GetBookingId(),
+				ticket.GetDepartureId(),
+				seatName(ticket.GetSeat().GetSeatNumber()),
+				ticket.GetUser().GetEmail(),
+				review.GetAccount(),
+				review.GetClientIp(),
+				review.GetPaymentFingerprint(),
+				review.GetReason())
+		}
+	})
+}
+
+// runAdminReview approves or rejects a booking held for review.
+func runAdminReview(c *cli, args []string) error {
+	flags := flag.NewFlagSet("admin review", flag.ExitOnError)
+	booking := flags.String("booking", "", "booking `ID`")
+	decisionName := flags.String("decision", "", "approve to take payment and confirm the booking, or reject to free its seat")
+	version := flags.Int64("expected-version", 0, "only review the booking if it is at this `version`")
+	if err := parseFlags(flags, args, "booking", "decision"); err != nil {
+		return err
+	}
+
+	decisions := map[string]v1.ReviewDecision{
+		"approve": v1.ReviewDecision_REVIEW_DECISION_APPROVE,
+		"reject":  v1.ReviewDecision_REVIEW_DECISION_REJECT,
+	}
+	decision, ok := decisions[strings.ToLower(*decisionName)]
+	if !ok {
+		return fmt.Errorf("unknown decision %q", *decisionName)
+	}
+	res, err := c.client.ReviewBooking(context.Background(), connect.NewRequest(&v1.ReviewBookingRequest{
+		BookingId:       *booking,
+		Decision:        decision,
+		ExpectedVersion: *version,
+	}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) {
+		receiptTable(w, res.Msg.GetReceipt())
+	})
+}
+
+// runSearch finds passengers with confirmed bookings.
+func runSearch(c *cli, args []string) error {
+	flags := flag.NewFlagSet("search", flag.ExitOnError)
+	booking := flags.String("booking", "", "booking `ID`")
+	seat := flags.Int("seat", 0, "seat `number`")
+	departure := flags.String("departure", "", "only passengers on departure `ID`")
+	limit := flags.Int("limit", 0, "return at most `n` matches")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: ticketing search [flags] [words...]")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	res, err := c.client.SearchPassengers(context.Background(), connect.NewRequest(&v1.SearchPassengersRequest{
+		Query:       strings.Join(flags.Args(), " "),
+		BookingId:   *booking,
+		SeatNumber:  int32(*seat),
+		DepartureId: *departure,
+		Limit:       int32(*limit),
+	}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) {
+		var receipts []*v1.Receipt
+		for _, match := range res.Msg.GetMatches() {
+			receipts = append(receipts, match.GetBooking())
+		}
+		receiptTable(w, receipts...)
+	})
+}
+
+// parseTime reads an RFC 3339 time. An empty string is no time.
+func parseTime(value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// runPurchase buys a ticket.
+func runPurchase(c *cli, args []string) error {
+	flags := flag.NewFlagSet("purchase", flag.ExitOnError)
+	user := userFlags(flags)
+	departure := flags.String("departure", "", "departure `ID`; the default departure when unset")
+	discount := flags.String("discount", "", "discount `code`")
+	payment := flags.String("payment-token", "", "payment method `token`")
+	key := flags.String("idempotency-key", "", "`key` that makes retrying the purchase safe")
+	if err := parseFlags(flags, args, "first", "last", "email"); err != nil {
+		return err
+	}
+
+	req := connect.NewRequest(&v1.PurchaseTicketRequest{
+		Ticket: &v1.Ticket{User: user, DepartureId: *departure, DiscountCode: *discount},
+	})
+	if *payment != "" {
+		req.Msg.PaymentMethod = &v1.PaymentMethod{Token: *payment}
+	}
+	if *key != "" {
+		req.Header().Set("Idempotency-Key", *key)
+	}
+	res, err := c.client.PurchaseTicket(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) { receiptTable(w, res.Msg.GetReceipt()) })
+}
+
+// runReceipt shows the receipt of a passenger's booking.
+func runReceipt(c *cli, args []string) error {
+	flags := flag.NewFlagSet("receipt", flag.ExitOnError)
+	user := userFlags(flags)
+	if err := parseFlags(flags, args, "first", "last", "email"); err != nil {
+		return err
+	}
+
+	res, err := c.client.ViewReceipt(context.Background(), connect.NewRequest(&v1.ViewReceiptRequest{
+		Ticket: &v1.Ticket{User: user},
+	}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) { receiptTable(w, res.Msg.GetReceipt()) })
+}
+
+// runRemove removes a passenger, found by first name, from the train.
+func runRemove(c *cli, args []string) error {
+	flags := flag.NewFlagSet("remove", flag.ExitOnError)
+	first := flags.String("first", "", "passenger's first `name`")
+	version := flags.Int64("expected-version", 0, "only remove the passenger if their bookings are at this `version`")
+	if err := parseFlags(flags, args, "first"); err != nil {
+		return err
+	}
+
+	res, err := c.client.RemoveUser(context.Background(), connect.NewRequest(&v1.RemoveUserRequest{
+		User:            &v1.User{FirstName: *first},
+		ExpectedVersion: *version,
+	}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) { receiptTable(w, res.Msg.GetReceipt()) })
+}
+
+// runModifySeat moves a passenger, found by first name, to a seat or to the
+// first free seat in a section.
+func runModifySeat(c *cli, args []string) error {
+	flags := flag.NewFlagSet("modify-seat", flag.ExitOnError)
+	first := flags.String("first", "", "passenger's first `name`")
+	seat := flags.Int("seat", 0, "seat `number` to move to")
+	section := flags.String("section", "", "move to the first free seat in `section` A or B")
+	version := flags.Int64("expected-version", 0, "only move the passenger if their booking is at this `version`")
+	if err := parseFlags(flags, args, "first"); err != nil {
+		return err
+	}
+	sectionType, err := parseSection(*section)
+	if err != nil {
+		return err
+	}
+	if (*seat == 0) == (sectionType == v1.Section_SECTION_TYPE_UNSPECIFIED) {
+		return fmt.Errorf("either -seat or -section must be set")
+	}
+
+	res, err := c.client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:            &v1.User{FirstName: *first},
+		NewSeatNumber:   int32(*seat),
+		SectionType:     sectionType,
+		ExpectedVersion: *version,
+	}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) { receiptTable(w, res.Msg.GetReceipt()) })
+}
+
+// runCancel cancels a booking.
+func runCancel(c *cli, args []string) error {
+	flags := flag.NewFlagSet("cancel", flag.ExitOnError)
+	booking := flags.String("booking", "", "booking `ID`")
+	version := flags.Int64("expected-version", 0, "only cancel the booking if it is at this `version`")
+	if err := parseFlags(flags, args, "booking"); err != nil {
+		return err
+	}
+
+	res, err := c.client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{
+		BookingId:       *booking,
+		ExpectedVersion: *version,
+	}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) {
+		receipt := res.Msg.GetCancellationReceipt()
+		fmt.Fprintln(w, "BOOKING\tCANCELLED\tREFUND\tVERSION")
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%d\n", receipt.GetBookingId(), formatTime(receipt.GetCancelledAt()), receipt.GetRefundAmount(), receipt.GetVersion())
+	})
+}
+
+// runExchange moves a booking to another departure.
+func runExchange(c *cli, args []string) error {
+	flags := flag.NewFlagSet("exchange", flag.ExitOnError)
+	booking := flags.String("booking", "", "booking `ID`")
+	departure := flags.String("departure", "", "departure `ID` to move to")
+	version := flags.Int64("expected-version", 0, "only exchange the booking if it is at this `version`")
+	if err := parseFlags(flags, args, "booking", "departure"); err != nil {
+		return err
+	}
+
+	res, err := c.client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:       *booking,
+		DepartureId:     *departure,
+		ExpectedVersion: *version,
+	}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) {
+		receiptTable(w, res.Msg.GetReceipt())
+		fmt.Fprintf(w, "\nfare difference: %.2f\n", res.Msg.GetFareDifference())
+	})
+}
+
+// userFlags adds the flags naming a passenger.
+func userFlags(flags *flag.FlagSet) *v1.User {
+	user := &v1.User{}
+	flags.StringVar(&user.FirstName, "first", "", "passenger's first `name`")
+	flags.StringVar(&user.LastName, "last", "", "passenger's last `name`")
+	flags.StringVar(&user.Email, "email", "", "passenger's `email`")
+	return user
+}
+
+// receiptTable lays out receipts one per line.
+func receiptTable(w io.Writer, receipts ...*v1.Receipt) {
+	fmt.Fprintln(w, "BOOKING\tSTATUS\tDEPARTURE\tSEAT\tPASSENGER\tEMAIL\tPRICE\tPURCHASED\tVERSION")
+	for _, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		ticket := receipt.GetTicket()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s %s\t%s\t%.2f\t%s\t%d\n",
+			receipt.GetBookingId(),
+			statusName(receipt.GetStatus()),
+			ticket.GetDepartureId(),
+			seatName(ticket.GetSeat().GetSeatNumber()),
+			ticket.GetUser().GetFirstName(), ticket.GetUser().GetLastName(),
+			ticket.GetUser().GetEmail(),
+			ticket.GetPricePaid(),
+			formatTime(receipt.GetPurchasedAt()),
+			receipt.GetVersion())
+	}
+}
+
+// statusName gives a booking status in lower case. Receipts of dry runs have
+// no status.
+func statusName(status v1.BookingStatus) string {
+	if status == v1.BookingStatus_BOOKING_STATUS_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(status.String(), "BOOKING_STATUS_"))
+}
+
+// SEATS_PER_SECTION is the number of seats in section A, after which section B
+// starts.
+const SEATS_PER_SECTION = 10
+
+// seatName gives a seat number with its section, such as B12.
+func seatName(number int32) string {
+	if number > SEATS_PER_SECTION {
+		return fmt.Sprintf("B%d", number)
+	}
+	return fmt.Sprintf("A%d", number)
+}
+
+// parseSection reads a section letter. An empty string is no section.
+func parseSection(section string) (v1.Section_SectionType, error) {
+	switch strings.ToUpper(section) {
+	case "":
+		return v1.Section_SECTION_TYPE_UNSPECIFIED, nil
+	case "A":
+		return v1.Section_SECTION_TYPE_A, nil
+	case "B":
+		return v1.Section_SECTION_TYPE_B, nil
+	}
+	return 0, fmt.Errorf("unknown section %q", section)
+}
+
+func formatTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return ""
+	}
+	return t.AsTime().Local().Format(time.DateTime)
+}
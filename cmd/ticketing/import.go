@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// IMPORT_BATCH_SIZE is the number of rows sent in each message of an import.
+const IMPORT_BATCH_SIZE = 100
+
+// runImport books the passengers listed in a CSV file. The file starts with a
+// header naming its columns: first_name, last_name and email are required,
+// and departure_id, section, seat and price are optional.
+func runImport(c *cli, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "check the rows and show the seats they would get without booking them")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ticketing import [-dry-run] <file.csv | ->")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var in io.Reader = os.Stdin
+	if name := flags.Arg(0); name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	rows, err := readImportRows(in)
+	if err != nil {
+		return err
+	}
+
+	stream := c.client.ImportBookings(context.Background())
+	for start := 0; start < len(rows); start += IMPORT_BATCH_SIZE {
+		batch := rows[start:min(start+IMPORT_BATCH_SIZE, len(rows))]
+		if err := stream.Send(&v1.ImportBookingsRequest{Rows: batch, DryRun: *dryRun}); err != nil {
+			break // The error is returned by CloseAndReceive
+		}
+	}
+	res, err := stream.CloseAndReceive()
+	if err != nil {
+		return err
+	}
+
+	summary := res.Msg
+	err = c.print(summary, func(w io.Writer) {
+		for _, rowErr := range summary.GetErrors() {
+			fmt.Fprintf(w, "row %d:\t%s\n", rowErr.GetRow(), rowErr.GetMessage())
+		}
+		if len(summary.GetErrors()) > 0 {
+			return
+		}
+		receiptTable(w, summary.GetReceipts()...)
+		verb := "booked"
+		if summary.GetDryRun() {
+			verb = "would book"
+		}
+		fmt.Fprintf(w, "\n%s %d passengers for %.2f\n", verb, len(summary.GetReceipts()), summary.GetTotalPrice())
+	})
+	if err == nil && len(summary.GetErrors()) > 0 {
+		err = fmt.Errorf("%d of %d rows have errors, nothing was booked", len(summary.GetErrors()), summary.GetRows())
+	}
+	return err
+}
+
+// readImportRows parses the rows of an import file.
+func readImportRows(in io.Reader) ([]*v1.ImportBookingRow, error) {
+	records, err := csv.NewReader(in).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"first_name", "last_name", "email"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the header has no %s column", name)
+		}
+	}
+
+	var rows []*v1.ImportBookingRow
+	for line, record := range records[1:] {
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := &v1.ImportBookingRow{
+			User: &v1.User{
+				FirstName: field("first_name"),
+				LastName:  field("last_name"),
+				Email:     field("email"),
+			},
+			DepartureId: field("departure_id"),
+		}
+		if row.SectionType, err = parseSection(field("section")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line+2, err)
+		}
+		if seat := field("seat"); seat != "" {
+			number, err := strconv.ParseInt(seat, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid seat %q", line+2, seat)
+			}
+			row.SeatNumber = int32(number)
+		}
+		if price := field("price"); price != "" {
+			amount, err := strconv.ParseFloat(price, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid price %q", line+2, price)
+			}
+			override := float32(amount)
+			row.PriceOverride = &override
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
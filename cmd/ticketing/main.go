@@ -0,0 +1,208 @@
+// Command ticketing is a client for the train ticketing service.
+//
+// Usage:
+//
+//	ticketing [flags] <command> [command flags]
+//
+// The flags default to $TICKETING_ADDR, $TICKETING_TOKEN,
+// $TICKETING_TOKEN_FILE, $TICKETING_PROTOCOL and $TICKETING_OUTPUT. Run
+// ticketing -h for the list of commands, and ticketing <command> -h for the
+// flags of a command.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	connect "connectrpc.com/connect"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+)
+
+// DEFAULT_ADDR is the server used when neither -addr nor $TICKETING_ADDR is set.
+const DEFAULT_ADDR = "http://localhost:8080"
+
+// cli is what every command runs with.
+type cli struct {
+	client ticketingv1.TrainTicketingServiceClient
+	json   bool // Print responses as JSON instead of tables
+	out    io.Writer
+}
+
+// command is a subcommand of the CLI. run gets the arguments after the
+// command's name.
+type command struct {
+	summary string
+	run     func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"purchase":    {"buy a ticket", runPurchase},
+	"receipt":     {"show the receipt of a passenger's booking", runReceipt},
+	"admin":       {"list the booked seats (admin list), the audit log (admin audit) or the bookings held for review (admin reviews), or review one (admin review)", runAdmin},
+	"remove":      {"remove a passenger from the train", runRemove},
+	"modify-seat": {"move a passenger to another seat", runModifySeat},
+	"seat-map":    {"show which seats of a departure are free", runSeatMap},
+	"pick-seat":   {"book seats picked from a live seat map in the terminal", runPickSeat},
+	"cancel":      {"cancel a booking", runCancel},
+	"exchange":    {"move a booking to another departure", runExchange},
+	"search":      {"find passengers by name, email, seat or booking", runSearch},
+	"import":      {"book the passengers listed in a CSV file", runImport},
+	"manifest":    {"export the passenger manifest of a departure", runManifest},
+}
+
+func main() {
+	flags := flag.NewFlagSet("ticketing", flag.ExitOnError)
+	addr := flags.String("addr", envOr("TICKETING_ADDR", DEFAULT_ADDR), "server `URL`")
+	token := flags.String("token", os.Getenv("TICKETING_TOKEN"), "bearer `token` sent with every request")
+	tokenFile := flags.String("token-file", os.Getenv("TICKETING_TOKEN_FILE"), "read the bearer token from `file` when -token isn't set")
+	protocol := flags.String("protocol", envOr("TICKETING_PROTOCOL", "connect"), "protocol to talk to the server with: connect, grpc or grpc-web")
+	output := flags.String("output", envOr("TICKETING_OUTPUT", "table"), "how to print responses: table or json")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ticketing [flags] <command> [command flags]")
+		fmt.Fprintln(os.Stderr, "\ncommands:")
+		var names []string
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].summary)
+		}
+		fmt.Fprintln(os.Stderr, "\nflags:")
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "ticketing: unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	c, err := newCLI(*addr, *token, *tokenFile, *protocol, *output)
+	if err == nil {
+		err = cmd.run(c, flags.Args()[1:])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ticketing:", err)
+		os.Exit(1)
+	}
+}
+
+// newCLI sets up the client from the global flags.
+func newCLI(addr, token, tokenFile, protocol, output string) (*cli, error) {
+	if token == "" && tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
+	var opts []connect.ClientOption
+	switch protocol {
+	case "connect":
+	case "grpc":
+		opts = append(opts, connect.WithGRPC())
+		// gRPC needs HTTP/2, which servers without TLS only speak when
+		// the client starts with it
+		if strings.HasPrefix(addr, "http://") {
+			transport = &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, network, addr)
+				},
+			}
+		}
+	case "grpc-web":
+		opts = append(opts, connect.WithGRPCWeb())
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", protocol)
+	}
+	if output != "table" && output != "json" {
+		return nil, fmt.Errorf("unknown output %q", output)
+	}
+
+	httpClient := &http.Client{Transport: &bearerTransport{token: token, next: transport}}
+	return &cli{
+		client: ticketingv1.NewTrainTicketingServiceClient(httpClient, addr, opts...),
+		json:   output == "json",
+		out:    os.Stdout,
+	}, nil
+}
+
+// print writes msg as JSON, or as the table laid out by table.
+func (c *cli) print(msg proto.Message, table func(w io.Writer)) error {
+	if c.json {
+		data, err := protojson.MarshalOptions{Multiline: true}.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		_, err = c.out.Write(append(data, '\n'))
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	table(w)
+	return w.Flush()
+}
+
+// bearerTransport adds the Authorization header to every request.
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.token != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// parseFlags parses a command's flags and checks the required ones were given.
+func parseFlags(flags *flag.FlagSet, args []string, required ...string) error {
+	flags.Parse(args)
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	var missing []string
+	for _, name := range required {
+		if !set[name] {
+			missing = append(missing, "-"+name)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New(strings.Join(missing, ", ") + " must be set")
+	}
+	return nil
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
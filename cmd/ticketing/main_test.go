@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	connect "connectrpc.com/connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+func TestNewCLIToken(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name, token, tokenFile, want string
+	}{
+		{"no token", "", "", ""},
+		{"token", "s3cret", "", "Bearer s3cret"},
+		{"token file", "", tokenFile, "Bearer from-file"},
+		{"token over token file", "s3cret", tokenFile, "Bearer s3cret"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := newCLI(srv.URL, tc.token, tc.tokenFile, "connect", "table")
+			if err != nil {
+				t.Fatalf("newCLI failed: %v", err)
+			}
+			got = "unset"
+			c.client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{}))
+			if got != tc.want {
+				t.Fatalf("expected Authorization %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewCLIErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, tokenFile, protocol, output, want string
+	}{
+		{"missing token file", filepath.Join(t.TempDir(), "missing"), "connect", "table", "no such file"},
+		{"unknown protocol", "", "carrier-pigeon", "table", `unknown protocol "carrier-pigeon"`},
+		{"unknown output", "", "grpc", "yaml", `unknown output "yaml"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newCLI(DEFAULT_ADDR, "", tc.tokenFile, tc.protocol, tc.output)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected an error mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+		want string // In the error, if any
+	}{
+		{"required set", []string{"-email", "jane@example.com", "-seat", "7"}, ""},
+		{"required missing", []string{"-seat", "7"}, "-email must be set"},
+		{"all missing", nil, "-email, -seat must be set"},
+		{"arguments", []string{"-email", "jane@example.com", "-seat", "7", "extra"}, "unexpected arguments"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.String("email", "", "")
+			flags.Int("seat", 0, "")
+			err := parseFlags(flags, tc.args, "email", "seat")
+			switch {
+			case tc.want == "" && err != nil:
+				t.Fatalf("parseFlags failed: %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Fatalf("expected an error mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
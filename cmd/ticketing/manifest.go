@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	connect "connectrpc.com/connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// runManifest streams a departure's manifest to a file or stdout.
+func runManifest(c *cli, args []string) error {
+	flags := flag.NewFlagSet("manifest", flag.ExitOnError)
+	departure := flags.String("departure", "", "departure `ID`; the default departure when unset")
+	format := flags.String("format", "csv", "output format: csv, jsonl or text")
+	lines := flags.Int("lines", 0, "`lines` per page of the text format; the server's default when unset")
+	out := flags.String("o", "", "write to `file` instead of stdout")
+	flags.Parse(args)
+
+	formats := map[string]v1.ManifestFormat{
+		"csv":   v1.ManifestFormat_MANIFEST_FORMAT_CSV,
+		"jsonl": v1.ManifestFormat_MANIFEST_FORMAT_JSONL,
+		"text":  v1.ManifestFormat_MANIFEST_FORMAT_TEXT,
+	}
+	manifestFormat, ok := formats[strings.ToLower(*format)]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	res, err := c.client.ExportManifest(context.Background(), connect.NewRequest(&v1.ExportManifestRequest{
+		DepartureId:  *departure,
+		Format:       manifestFormat,
+		LinesPerPage: int32(*lines),
+	}))
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	w := c.out
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	for res.Receive() {
+		if _, err := w.Write(res.Msg().GetData()); err != nil {
+			return err
+		}
+	}
+	if err := res.Err(); err != nil {
+		return err
+	}
+	if *out != "" {
+		return w.(*os.File).Close()
+	}
+	return nil
+}
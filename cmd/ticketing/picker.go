@@ -0,0 +1,410 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	connect "connectrpc.com/connect"
+	"golang.org/x/term"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// DEFAULT_PICKER_REFRESH is how often the seat picker reloads the seat map
+// unless -refresh says otherwise.
+const DEFAULT_PICKER_REFRESH = 2 * time.Second
+
+// PICKER_ROW_SEATS is the number of seats drawn on each row of the grid.
+const PICKER_ROW_SEATS = 5
+
+// runPickSeat books a seat picked from a seat map drawn in the terminal. The
+// map is reloaded every few seconds, so seats booked by others show up as
+// taken while the agent is picking.
+func runPickSeat(c *cli, args []string) error {
+	flags := flag.NewFlagSet("pick-seat", flag.ExitOnError)
+	departure := flags.String("departure", "", "departure `ID`; the default departure when unset")
+	discount := flags.String("discount", "", "discount `code` applied to every ticket booked")
+	payment := flags.String("payment-token", "", "payment method `token` used for every ticket booked")
+	refresh := flags.Duration("refresh", DEFAULT_PICKER_REFRESH, "how often to reload the seat map")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *refresh <= 0 {
+		return errors.New("-refresh must be positive")
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return errors.New("pick-seat needs a terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	p := &picker{
+		cli:         c,
+		out:         os.Stdout,
+		departureID: *departure,
+		discount:    *discount,
+		payment:     *payment,
+	}
+	err = p.run(readKeys(os.Stdin), time.NewTicker(*refresh).C)
+
+	// Leave the screen clear of the grid for whatever runs next
+	fmt.Fprint(p.out, "\x1b[H\x1b[2J")
+	return err
+}
+
+// pickerField is one of the passenger details asked for once a seat is picked.
+type pickerField struct {
+	label string
+	value string
+}
+
+// picker is the state of the seat picker.
+type picker struct {
+	cli         *cli
+	out         io.Writer
+	departureID string
+	discount    string
+	payment     string
+
+	seatMap  *v1.ViewSeatMapResponse
+	loadedAt time.Time
+	cursor   int // Index into seatMap.Seats
+
+	// Once a seat is picked, the passenger's details are entered field by
+	// field. They are kept after a failed purchase so they needn't be typed
+	// again.
+	picked  *v1.SeatMapSeat
+	fields  []pickerField
+	editing int
+
+	status string
+}
+
+// run draws the picker and handles keys until the agent quits.
+func (p *picker) run(keys <-chan string, refresh <-chan time.Time) error {
+	p.resetFields()
+	if err := p.load(); err != nil {
+		return err
+	}
+	p.moveToFreeSeat()
+	for {
+		p.draw()
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			quit, err := p.handle(key)
+			if quit || err != nil {
+				return err
+			}
+		case <-refresh:
+			if err := p.load(); err != nil {
+				p.status = "Failed to refresh the seat map: " + err.Error()
+			}
+		}
+	}
+}
+
+// load fetches the seat map. When the picked seat has been taken in the
+// meantime, the agent goes back to picking a seat.
+func (p *picker) load() error {
+	res, err := p.cli.client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{
+		DepartureId: p.departureID,
+	}))
+	if err != nil {
+		return err
+	}
+	p.seatMap = res.Msg
+	p.loadedAt = time.Now()
+	if p.cursor >= len(p.seatMap.GetSeats()) {
+		p.cursor = 0
+	}
+	if p.picked != nil && p.seatTaken(p.picked.GetSeatNumber()) {
+		p.status = fmt.Sprintf("Seat %s was just booked by someone else, pick another", seatName(p.picked.GetSeatNumber()))
+		p.picked = nil
+	}
+	return nil
+}
+
+func (p *picker) seatTaken(number int32) bool {
+	for _, seat := range p.seatMap.GetSeats() {
+		if seat.GetSeatNumber() == number {
+			return seat.GetTaken()
+		}
+	}
+	return true
+}
+
+// moveToFreeSeat puts the cursor on the first free seat, if there is one.
+func (p *picker) moveToFreeSeat() {
+	for i, seat := range p.seatMap.GetSeats() {
+		if !seat.GetTaken() {
+			p.cursor = i
+			return
+		}
+	}
+}
+
+func (p *picker) resetFields() {
+	p.fields = []pickerField{{label: "First name"}, {label: "Last name"}, {label: "Email"}}
+	p.editing = 0
+}
+
+// handle acts on a key, and reports whether the agent asked to quit.
+func (p *picker) handle(key string) (bool, error) {
+	if key == "ctrl-c" {
+		return true, nil
+	}
+	if p.picked != nil {
+		return false, p.handleDetailsKey(key)
+	}
+
+	seats := p.seatMap.GetSeats()
+	switch key {
+	case "q":
+		return true, nil
+	case "left", "h":
+		p.cursor = max(p.cursor-1, 0)
+	case "right", "l":
+		p.cursor = min(p.cursor+1, len(seats)-1)
+	case "up", "k":
+		if p.cursor >= PICKER_ROW_SEATS {
+			p.cursor -= PICKER_ROW_SEATS
+		}
+	case "down", "j":
+		if p.cursor+PICKER_ROW_SEATS < len(seats) {
+			p.cursor += PICKER_ROW_SEATS
+		}
+	case "r":
+		if err := p.load(); err != nil {
+			p.status = "Failed to refresh the seat map: " + err.Error()
+		}
+	case "enter", " ":
+		if len(seats) == 0 {
+			return false, nil
+		}
+		seat := seats[p.cursor]
+		if seat.GetTaken() {
+			p.status = fmt.Sprintf("Seat %s is taken", seatName(seat.GetSeatNumber()))
+			return false, nil
+		}
+		p.picked = seat
+		p.status = ""
+	}
+	return false, nil
+}
+
+// handleDetailsKey edits the passenger's details, and books the picked seat
+// once the last one is entered.
+func (p *picker) handleDetailsKey(key string) error {
+	field := &p.fields[p.editing]
+	switch key {
+	case "esc":
+		p.picked = nil
+		p.status = ""
+	case "backspace":
+		_, size := utf8.DecodeLastRuneInString(field.value)
+		field.value = field.value[:len(field.value)-size]
+	case "up":
+		p.editing = max(p.editing-1, 0)
+	case "down", "tab":
+		p.editing = min(p.editing+1, len(p.fields)-1)
+	case "enter":
+		if strings.TrimSpace(field.value) == "" {
+			p.status = field.label + " must be set"
+			return nil
+		}
+		p.status = ""
+		if p.editing < len(p.fields)-1 {
+			p.editing++
+			return nil
+		}
+		p.purchase()
+	default:
+		if r, size := utf8.DecodeRuneInString(key); size == len(key) && unicode.IsPrint(r) {
+			field.value += key
+		}
+	}
+	return nil
+}
+
+// purchase books the picked seat for the passenger whose details were
+// entered.
+func (p *picker) purchase() {
+	for _, field := range p.fields {
+		if strings.TrimSpace(field.value) == "" {
+			p.status = field.label + " must be set"
+			return
+		}
+	}
+	req := connect.NewRequest(&v1.PurchaseTicketRequest{
+		Ticket: &v1.Ticket{
+			User: &v1.User{
+				FirstName: strings.TrimSpace(p.fields[0].value),
+				LastName:  strings.TrimSpace(p.fields[1].value),
+				Email:     strings.TrimSpace(p.fields[2].value),
+			},
+			DepartureId:  p.seatMap.GetDeparture().GetId(),
+			Seat:         &v1.Seat{SeatNumber: p.picked.GetSeatNumber()},
+			DiscountCode: p.discount,
+		},
+	})
+	if p.payment != "" {
+		req.Msg.PaymentMethod = &v1.PaymentMethod{Token: p.payment}
+	}
+	res, err := p.cli.client.PurchaseTicket(context.Background(), req)
+	if err != nil {
+		p.status = "Purchase failed: " + err.Error()
+		// Someone else got the seat first, so another one has to be picked
+		if connect.CodeOf(err) == connect.CodeFailedPrecondition {
+			p.picked = nil
+			_ = p.load()
+		}
+		return
+	}
+
+	receipt := res.Msg.GetReceipt()
+	ticket := receipt.GetTicket()
+	p.status = fmt.Sprintf("Booked %s for %s %s: booking %s, %.2f",
+		seatName(ticket.GetSeat().GetSeatNumber()),
+		ticket.GetUser().GetFirstName(), ticket.GetUser().GetLastName(),
+		receipt.GetBookingId(), ticket.GetPricePaid())
+	p.picked = nil
+	p.resetFields()
+	if err := p.load(); err != nil {
+		p.status += " (failed to refresh the seat map: " + err.Error() + ")"
+	}
+	p.moveToFreeSeat()
+}
+
+// draw redraws the whole screen. The terminal is in raw mode, so lines end in
+// \r\n.
+func (p *picker) draw() {
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+
+	d := p.seatMap.GetDeparture()
+	fmt.Fprintf(&b, "%s: %s to %s, leaving %s\n", d.GetId(), d.GetFrom(), d.GetTo(), formatTime(d.GetDepartureTime()))
+	fmt.Fprintf(&b, "%d of %d seats free, updated %s\n", p.seatMap.GetFreeSeats(), len(p.seatMap.GetSeats()), p.loadedAt.Format(time.TimeOnly))
+
+	section := v1.Section_SECTION_TYPE_UNSPECIFIED
+	inRow := 0
+	for i, seat := range p.seatMap.GetSeats() {
+		if seat.GetSection() != section || inRow == PICKER_ROW_SEATS {
+			if inRow > 0 {
+				b.WriteString("\n")
+			}
+			if seat.GetSection() != section {
+				section = seat.GetSection()
+				fmt.Fprintf(&b, "\nSection %s\n", strings.TrimPrefix(section.String(), "SECTION_TYPE_"))
+			}
+			inRow = 0
+		}
+		inRow++
+
+		cell := seatName(seat.GetSeatNumber())
+		if seat.GetTaken() {
+			cell = "--"
+		}
+		cell = fmt.Sprintf(" %-4s", cell)
+		switch {
+		case p.picked != nil && seat.GetSeatNumber() == p.picked.GetSeatNumber():
+			// Bold and reversed: the seat being booked
+			fmt.Fprintf(&b, "\x1b[1;7m%s\x1b[0m", cell)
+		case p.picked == nil && i == p.cursor:
+			fmt.Fprintf(&b, "\x1b[7m%s\x1b[0m", cell)
+		default:
+			b.WriteString(cell)
+		}
+	}
+	b.WriteString("\n\n")
+
+	if p.picked == nil {
+		b.WriteString("arrows or hjkl: move  enter: book the seat  r: refresh  q: quit\n")
+	} else {
+		fmt.Fprintf(&b, "Booking seat %s\n", seatName(p.picked.GetSeatNumber()))
+		for i, field := range p.fields {
+			marker := "  "
+			if i == p.editing {
+				marker = "> "
+			}
+			fmt.Fprintf(&b, "%s%-11s %s\n", marker, field.label+":", field.value)
+		}
+		b.WriteString("enter: next field, and book after the last  up/down: move between fields  esc: pick another seat\n")
+	}
+	if p.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", p.status)
+	}
+
+	io.WriteString(p.out, strings.ReplaceAll(b.String(), "\n", "\r\n"))
+}
+
+// readKeys reads key presses from r, which must be a terminal in raw mode,
+// and sends their names: arrows are "up", "down", "left" and "right", and
+// other keys are "enter", "tab", "esc", "backspace" and "ctrl-c", or the
+// character typed. The channel is closed when r is.
+func readKeys(r io.Reader) <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 64)
+		for {
+			n, err := r.Read(buf)
+			for _, key := range decodeKeys(buf[:n]) {
+				keys <- key
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return keys
+}
+
+// decodeKeys names the keys in a chunk read from a terminal in raw mode.
+func decodeKeys(data []byte) []string {
+	var keys []string
+	for len(data) > 0 {
+		switch {
+		case data[0] == 0x1b && len(data) >= 3 && data[1] == '[':
+			if name, ok := map[byte]string{'A': "up", 'B': "down", 'C': "right", 'D': "left"}[data[2]]; ok {
+				keys = append(keys, name)
+			}
+			data = data[3:]
+			continue
+		case data[0] == 0x1b:
+			keys = append(keys, "esc")
+		case data[0] == '\r' || data[0] == '\n':
+			keys = append(keys, "enter")
+		case data[0] == '\t':
+			keys = append(keys, "tab")
+		case data[0] == 0x7f || data[0] == 0x08:
+			keys = append(keys, "backspace")
+		case data[0] == 0x03:
+			keys = append(keys, "ctrl-c")
+		case data[0] >= 0x20:
+			r, size := utf8.DecodeRune(data)
+			if r != utf8.RuneError {
+				keys = append(keys, string(r))
+			}
+			data = data[size:]
+			continue
+		}
+		data = data[1:]
+	}
+	return keys
+}
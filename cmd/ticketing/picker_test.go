@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	connect "connectrpc.com/connect"
+
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// pickerClient serves the picker a seat map of eight seats, of which A2 and
+// A7 are taken, and books seats without a server.
+type pickerClient struct {
+	ticketingv1.TrainTicketingServiceClient
+	purchaseErr error
+	purchased   []*v1.PurchaseTicketRequest
+}
+
+func (c *pickerClient) ViewSeatMap(ctx context.Context, req *connect.Request[v1.ViewSeatMapRequest]) (*connect.Response[v1.ViewSeatMapResponse], error) {
+	res := &v1.ViewSeatMapResponse{Departure: &v1.Departure{Id: "d1"}}
+	for number := int32(1); number <= 8; number++ {
+		res.Seats = append(res.Seats, &v1.SeatMapSeat{
+			SeatNumber: number,
+			Section:    v1.Section_SECTION_TYPE_A,
+			Taken:      number == 2 || number == 7,
+		})
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (c *pickerClient) PurchaseTicket(ctx context.Context, req *connect.Request[v1.PurchaseTicketRequest]) (*connect.Response[v1.PurchaseTicketResponse], error) {
+	if c.purchaseErr != nil {
+		return nil, c.purchaseErr
+	}
+	c.purchased = append(c.purchased, req.Msg)
+	return connect.NewResponse(&v1.PurchaseTicketResponse{
+		Receipt: &v1.Receipt{BookingId: "b1", Ticket: req.Msg.GetTicket()},
+	}), nil
+}
+
+// fieldValues returns what has been entered in each of the passenger's
+// details.
+func fieldValues(p *picker) []string {
+	var values []string
+	for _, field := range p.fields {
+		values = append(values, field.value)
+	}
+	return values
+}
+
+func TestPickerHandle(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		purchaseErr error
+		keys        []string
+		quit        bool
+		cursor      int
+		picked      int32    // Seat number, or 0 when no seat is picked
+		values      []string // Of the passenger's details
+		editing     int
+		status      string // Prefix of the status line
+		purchased   int
+	}{
+		{name: "move right", keys: []string{"right", "l"}, cursor: 2},
+		{name: "stop at the first seat", keys: []string{"right", "left", "h"}, cursor: 0},
+		{name: "stop at the last seat", keys: strings.Split("l l l l l l l l l l", " "), cursor: 7},
+		{name: "move down a row", keys: []string{"down"}, cursor: 5},
+		{name: "stay on the last row", keys: []string{"j", "j"}, cursor: 5},
+		{name: "move up a row", keys: []string{"l", "j", "k"}, cursor: 1},
+		{name: "stay on the first row", keys: []string{"up"}, cursor: 0},
+		{name: "quit", keys: []string{"q"}, quit: true},
+		{name: "pick a free seat", keys: []string{"enter"}, picked: 1},
+		{name: "pick with space", keys: []string{"l", "l", " "}, cursor: 2, picked: 3},
+		{name: "pick a taken seat", keys: []string{"l", "enter"}, cursor: 1, status: "Seat A2 is taken"},
+		{name: "type details", keys: []string{"enter", "J", "a", "n", "e", "backspace", "tab", "R"}, picked: 1, values: []string{"Jan", "R", ""}, editing: 1},
+		{name: "details keep letters that move the cursor", keys: []string{"enter", "q", "j", "k"}, picked: 1, values: []string{"qjk", "", ""}},
+		{name: "move between details", keys: []string{"enter", "down", "down", "down", "up"}, picked: 1, values: []string{"", "", ""}, editing: 1},
+		{name: "details must be set", keys: []string{"enter", "enter"}, picked: 1, values: []string{"", "", ""}, status: "First name must be set"},
+		{name: "pick another seat", keys: []string{"enter", "J", "esc", "l", "l"}, cursor: 2, values: []string{"J", "", ""}},
+		{name: "quit while entering details", keys: []string{"enter", "ctrl-c"}, quit: true, picked: 1, values: []string{"", "", ""}},
+		{
+			name:      "book",
+			keys:      []string{"enter", "J", "enter", "R", "enter", "j", "@", "x", "enter"},
+			values:    []string{"", "", ""},
+			status:    "Booked A1 for J R: booking b1",
+			purchased: 1,
+		},
+		{
+			name:        "book a seat taken in the meantime",
+			purchaseErr: connect.NewError(connect.CodeFailedPrecondition, errors.New("seat is taken")),
+			keys:        []string{"enter", "J", "enter", "R", "enter", "j", "enter"},
+			values:      []string{"J", "R", "j"},
+			editing:     2,
+			status:      "Purchase failed",
+		},
+		{
+			name:        "book when the server is down",
+			purchaseErr: connect.NewError(connect.CodeUnavailable, errors.New("no server")),
+			keys:        []string{"enter", "J", "enter", "R", "enter", "j", "enter"},
+			picked:      1,
+			values:      []string{"J", "R", "j"},
+			editing:     2,
+			status:      "Purchase failed",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &pickerClient{purchaseErr: tc.purchaseErr}
+			p := &picker{cli: &cli{client: client}, out: io.Discard}
+			p.resetFields()
+			if err := p.load(); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+
+			quit := false
+			for _, key := range tc.keys {
+				var err error
+				if quit, err = p.handle(key); err != nil {
+					t.Fatalf("handle(%q) failed: %v", key, err)
+				}
+				p.draw()
+			}
+
+			if quit != tc.quit || p.cursor != tc.cursor || p.picked.GetSeatNumber() != tc.picked {
+				t.Fatalf("expected quit %v, cursor %d and seat %d picked, got %v, %d and %d",
+					tc.quit, tc.cursor, tc.picked, quit, p.cursor, p.picked.GetSeatNumber())
+			}
+			if tc.values == nil {
+				tc.values = []string{"", "", ""}
+			}
+			if values := fieldValues(p); !reflect.DeepEqual(values, tc.values) || p.editing != tc.editing {
+				t.Fatalf("expected details %q editing %d, got %q editing %d", tc.values, tc.editing, values, p.editing)
+			}
+			if !strings.HasPrefix(p.status, tc.status) || (tc.status == "") != (p.status == "") {
+				t.Fatalf("expected status %q, got %q", tc.status, p.status)
+			}
+			if len(client.purchased) != tc.purchased {
+				t.Fatalf("expected %d purchases, got %d", tc.purchased, len(client.purchased))
+			}
+			for _, req := range client.purchased {
+				ticket := req.GetTicket()
+				if user := ticket.GetUser(); user.GetFirstName() != "J" || user.GetLastName() != "R" || user.GetEmail() != "j@x" || ticket.GetSeat().GetSeatNumber() != 1 || ticket.GetDepartureId() != "d1" {
+					t.Fatalf("expected J R j@x booked on seat 1 of d1, got %v", ticket)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		want []string
+	}{
+		{"\x1b[A\x1b[B\x1b[C\x1b[D", []string{"up", "down", "right", "left"}},
+		{"\r\n\t\x1b\x7f\x08\x03", []string{"enter", "enter", "tab", "esc", "backspace", "backspace", "ctrl-c"}},
+		{"jé ", []string{"j", "é", " "}},
+		{"\x1b[Hq", []string{"q"}},
+	} {
+		if got := decodeKeys([]byte(tc.data)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("decodeKeys(%q): expected %q, got %q", tc.data, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	connect "connectrpc.com/connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// runSeatMap shows which seats of a departure are free.
+func runSeatMap(c *cli, args []string) error {
+	flags := flag.NewFlagSet("seat-map", flag.ExitOnError)
+	departure := flags.String("departure", "", "departure `ID`; the default departure when unset")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	res, err := c.client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{DepartureId: *departure}))
+	if err != nil {
+		return err
+	}
+	return c.print(res.Msg, func(w io.Writer) {
+		info := res.Msg.GetDeparture()
+		fmt.Fprintf(w, "%s  %s -> %s  departs %s  %d seats free\n\n", info.GetId(), info.GetFrom(), info.GetTo(),
+			formatTime(info.GetDepartureTime()), res.Msg.GetFreeSeats())
+		seatMapTable(w, res.Msg.GetSeats())
+	})
+}
+
+// seatMapTable draws each section as a row of seats, with taken seats crossed
+// out.
+func seatMapTable(w io.Writer, seats []*v1.SeatMapSeat) {
+	for _, section := range []v1.Section_SectionType{v1.Section_SECTION_TYPE_A, v1.Section_SECTION_TYPE_B} {
+		fmt.Fprintf(w, "Section %s", section.String()[len("SECTION_TYPE_"):])
+		for _, seat := range seats {
+			if seat.GetSection() != section {
+				continue
+			}
+			if seat.GetTaken() {
+				fmt.Fprint(w, "\t--")
+			} else {
+				fmt.Fprintf(w, "\t%2d", seat.GetSeatNumber())
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
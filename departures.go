@@ -0,0 +1,208 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SEATS_PER_SECTION is the number of seats in each of the two train sections.
+// Seats 1-10 are in section A and seats 11-20 in section B.
+const SEATS_PER_SECTION = 10
+
+// DEFAULT_DEPARTURE_ID identifies the London to France departure created when
+// no schedule is configured.
+const DEFAULT_DEPARTURE_ID = "LDN-FRA"
+
+// departure is a scheduled train together with its seats.
+type departure struct {
+	info  *v1.Departure
+	seats []*v1.Seat // Seat number n is stored at index n-1
+
+	// bookings holds every booking on the departure, so a purchase only
+	// looks through those of its own train. It is guarded by h.mu, like
+	// h.bookings.
+	bookings map[string]*booking
+
+	// mu is held by requests that take seats on the departure or change its
+	// bookings, for as long as the request runs. It keeps the bookings as
+	// they were checked while h.mu is released for a payment or while an
+	// event is recorded. It must be taken before h.mu; see lockDepartures.
+	mu sync.Mutex
+}
+
+func newDeparture(info *v1.Departure) *departure {
+	d := &departure{info: info, bookings: make(map[string]*booking)}
+	for i := 1; i <= 2*SEATS_PER_SECTION; i++ {
+		d.seats = append(d.seats, &v1.Seat{SeatNumber: int32(i)})
+	}
+	return d
+}
+
+// SeatAllocator is implemented by ledgers that hand out seats to handlers
+// sharing them, such as PostgresLedger. A handler claims a seat from the
+// allocator before recording a booking for it, so handlers booking the same
+// train at once are given different seats.
+type SeatAllocator interface {
+	// AddSeats makes seats on a departure available. Seats that were added
+	// before are left as they are.
+	AddSeats(ctx context.Context, departureID string, seats []*v1.Seat) error
+	// ClaimSeat reserves the lowest numbered free seat on a departure for
+	// bookingID, preferring seats in section, and returns its number. It
+	// returns the seat already claimed for bookingID if there is one, and 0
+	// when the train is full.
+	ClaimSeat(ctx context.Context, departureID string, section v1.Section_SectionType, bookingID string) (int32, error)
+}
+
+// WithDepartures replaces the default London to France departure with the
+// given schedule. Tickets that don't name a departure are booked on the first
+// one.
+func WithDepartures(departures ...*v1.Departure) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.schedule = departures
+	}
+}
+
+// setupDepartures creates the seats for every scheduled departure.
+func (h *MyTrainTicketingServiceHandler) setupDepartures() error {
+	if len(h.schedule) == 0 {
+		departureTime := h.departureTime
+		if departureTime.IsZero() {
+			departureTime = h.now().Add(7 * 24 * time.Hour)
+		}
+		h.schedule = []*v1.Departure{{
+			Id:            DEFAULT_DEPARTURE_ID,
+			From:          "London",
+			To:            "France",
+			DepartureTime: timestamppb.New(departureTime),
+			Fare:          float32(h.SeatCost),
+		}}
+	}
+	allocator, _ := h.ledger.(SeatAllocator)
+	for _, info := range h.schedule {
+		d := newDeparture(info)
+		h.departures[info.GetId()] = d
+		if allocator != nil {
+			if err := allocator.AddSeats(context.Background(), info.GetId(), d.seats); err != nil {
+				return fmt.Errorf("failed to add seats for departure %q: %w", info.GetId(), err)
+			}
+		}
+	}
+	h.defaultDepartureID = h.schedule[0].GetId()
+	return nil
+}
+
+// lookupDeparture returns the departure with the given ID, or the default
+// departure when id is empty.
+func (h *MyTrainTicketingServiceHandler) lookupDeparture(id string) (*departure, error) {
+	if id == "" {
+		id = h.defaultDepartureID
+	}
+	d, ok := h.departures[id]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("departure %q not found", id))
+	}
+	return d, nil
+}
+
+// sectionOf returns the section a seat number belongs to.
+func sectionOf(seatNumber int32) v1.Section_SectionType {
+	if seatNumber <= SEATS_PER_SECTION {
+		return v1.Section_SECTION_TYPE_A
+	}
+	return v1.Section_SECTION_TYPE_B
+}
+
+// seat returns the seat with the given number, or nil if there is no such seat.
+func (d *departure) seat(number int32) *v1.Seat {
+	if number < 1 || int(number) > len(d.seats) {
+		return nil
+	}
+	return d.seats[number-1]
+}
+
+// freeSeat returns the lowest numbered free seat in section, or in any section
+// when section is unspecified. It returns nil when the train is full.
+func (d *departure) freeSeat(section v1.Section_SectionType) *v1.Seat {
+	for _, seat := range d.seats {
+		if seat.GetUser() != nil {
+			continue
+		}
+		if section == v1.Section_SECTION_TYPE_UNSPECIFIED || sectionOf(seat.GetSeatNumber()) == section {
+			return seat
+		}
+	}
+	return nil
+}
+
+// allocateSeat picks a free seat on d for bookingID, preferring seats in
+// section. Seats come from the ledger when it is a SeatAllocator. It returns
+// nil when the train is full. The caller must hold the lock of d and h.mu for
+// reading, which is released while the seat is claimed from the ledger.
+func (h *MyTrainTicketingServiceHandler) allocateSeat(ctx context.Context, d *departure, section v1.Section_SectionType, bookingID string) (seat *v1.Seat, err error) {
+	ctx, span := h.startSpan(ctx, "allocateSeat",
+		attribute.String("ticketing.departure", d.info.GetId()),
+		attribute.String("ticketing.section", section.String()),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("ticketing.seat", int(seat.GetSeatNumber())))
+		endSpan(span, err)
+	}()
+
+	allocator, ok := h.ledger.(SeatAllocator)
+	if !ok {
+		seat = d.freeSeat(section)
+		if seat == nil {
+			seat = d.freeSeat(v1.Section_SECTION_TYPE_UNSPECIFIED)
+		}
+		return seat, nil
+	}
+
+	var number int32
+	h.unlocked(func() {
+		number, err = allocator.ClaimSeat(ctx, d.info.GetId(), section, bookingID)
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to claim seat: %w", err))
+	}
+	if number == 0 {
+		return nil, nil
+	}
+	seat = d.seat(number)
+	if seat == nil || seat.GetUser() != nil {
+		return nil, connect.NewError(connect.CodeAborted, fmt.Errorf("seat %d was claimed but is not free, please retry", number))
+	}
+	return seat, nil
+}
+
+// departed reports whether the train has already left at the given time.
+func (d *departure) departed(at time.Time) bool {
+	return !at.Before(d.info.GetDepartureTime().AsTime())
+}
+
+// price returns what a ticket on d costs after applying discountCode. A missing
+// or unknown code means no discount.
+func (h *MyTrainTicketingServiceHandler) price(ctx context.Context, d *departure, discountCode string) float32 {
+	_, span := h.startSpan(ctx, "price",
+		attribute.String("ticketing.departure", d.info.GetId()),
+		attribute.String("ticketing.discount_code", discountCode),
+	)
+	defer span.End()
+
+	discount, _ := h.GetDiscount(discountCode)
+	price := float64(d.info.GetFare()) - discount
+	if price < 0 {
+		price = 0
+	}
+	span.SetAttributes(attribute.Float64("ticketing.price", price))
+	return float32(price)
+}
+
+var errNoSeats = errors.New("no available seats")
@@ -0,0 +1,142 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// ExchangeTicket implements the ExchangeTicket method of TrainTicketingServiceHandler.
+// It moves a confirmed booking onto another departure in one step: a seat on
+// the new train is found and any extra fare taken before the old seat is
+// released, and a lower fare refunded once the exchange is recorded, so a
+// failed exchange leaves the original booking untouched. Only admins and the
+// account that made the booking may exchange it.
+func (h *MyTrainTicketingServiceHandler) ExchangeTicket(ctx context.Context, req *connect.Request[v1.ExchangeTicketRequest]) (*connect.Response[v1.ExchangeTicketResponse], error) {
+	if req.Msg.GetBookingId() == "" || req.Msg.GetDepartureId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("booking ID and departure ID are required"))
+	}
+
+	// Lock both departures and the maps
+	bookings, unlock, err := h.lockBookings(ctx, func() ([]*booking, error) {
+		old, ok := h.bookings[req.Msg.GetBookingId()]
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("booking not found"))
+		}
+		return []*booking{old}, nil
+	}, req.Msg.GetDepartureId())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	old := bookings[0]
+
+	if err := requireAccount(ctx, old.account); err != nil {
+		return nil, err
+	}
+	if old.status != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("only confirmed bookings can be exchanged"))
+	}
+	if err := old.checkVersion(req.Msg.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+	if old.ticket.GetDepartureId() == req.Msg.GetDepartureId() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("booking is already on this departure"))
+	}
+
+	now := h.now()
+	if h.departures[old.ticket.GetDepartureId()].departed(now) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("original train has already departed"))
+	}
+	d, err := h.lookupDeparture(req.Msg.GetDepartureId())
+	if err != nil {
+		return nil, err
+	}
+	if d.departed(now) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("new train has already departed"))
+	}
+	if !h.owns(d.info.GetId()) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("new train is served by another cluster member"))
+	}
+
+	// Find the new seat before touching the old booking, in the same section
+	// if possible
+	exchangedID := newBookingID()
+	newSeat, err := h.allocateSeat(ctx, d, sectionOf(old.seat.GetSeatNumber()), exchangedID)
+	if err != nil {
+		return nil, err
+	}
+	if newSeat == nil {
+		return nil, connect.NewError(connect.CodeResourceExhausted, errNoSeats)
+	}
+
+	ticket := proto.Clone(old.ticket).(*v1.Ticket)
+	ticket.From = d.info.GetFrom()
+	ticket.To = d.info.GetTo()
+	ticket.DepartureId = d.info.GetId()
+	ticket.DepartureTime = d.info.GetDepartureTime()
+	ticket.PricePaid = h.price(ctx, d, ticket.GetDiscountCode())
+	fareDifference := float32(math.Round(float64(ticket.GetPricePaid()-old.ticket.GetPricePaid())*100) / 100)
+
+	// Take any extra fare without holding the maps; the departure locks keep
+	// both trains as they are. Nothing has changed yet if this fails. A lower
+	// fare is only refunded once the exchange is recorded, so a failed
+	// exchange never pays anything out.
+	paid, payments := old.payments, old.payments
+	var charged []*v1.Payment
+	if fareDifference > 0 {
+		h.unlocked(func() {
+			charged, err = h.charge(ctx, old.paymentMethod, fareDifference)
+		})
+		if err != nil {
+			return nil, err
+		}
+		payments = append(append([]*v1.Payment(nil), payments...), charged...)
+	} else if fareDifference < 0 {
+		payments, _ = splitRefund(payments, -fareDifference)
+	}
+	ticket.Seat = &v1.Seat{SeatNumber: newSeat.GetSeatNumber()}
+
+	// The new booking keeps the chain of booking IDs so it can be traced back
+	event := &v1.LedgerEvent{Event: &v1.LedgerEvent_BookingExchanged{BookingExchanged: &v1.BookingExchanged{
+		BookingId:    old.id,
+		NewBookingId: exchangedID,
+		Ticket:       ticket,
+		Payments:     payments,
+	}}}
+	version := old.version
+	for attempt := 1; ; attempt++ {
+		err = h.record(ctx, event)
+		// Changes other handlers made in the meantime only matter if they
+		// touched this booking or took the new seat
+		if errors.Is(err, ErrLedgerConflict) && attempt < LEDGER_CONFLICT_RETRIES && old.version == version && newSeat.GetUser() == nil {
+			continue
+		}
+		break
+	}
+	if err != nil {
+		// Hand back any extra fare taken; nothing else was changed
+		_, _ = h.refund(context.WithoutCancel(ctx), charged, fareDifference)
+		return nil, err
+	}
+	if fareDifference < 0 {
+		h.unlocked(func() {
+			_, err = h.refund(context.WithoutCancel(ctx), paid, -fareDifference)
+		})
+		if err != nil {
+			return nil, afterRecording(connect.CodeOf(err), fmt.Errorf("booking was exchanged but the fare difference couldn't be refunded: %w", err))
+		}
+	}
+	b := h.bookings[exchangedID]
+
+	response := &v1.ExchangeTicketResponse{
+		Receipt:        b.receipt(),
+		FareDifference: fareDifference,
+	}
+	return connect.NewResponse(response), nil
+}
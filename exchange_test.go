@@ -0,0 +1,182 @@
+package ticketing_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// exchangeDepartures are a morning and a dearer evening train to Paris, and a
+// cheaper one to Lille.
+func TestExchangeTicket(t *testing.T) {
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)))
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+	// Moving to the later, more expensive train charges the difference
+	response, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   bookingID,
+		DepartureId: "evening",
+	}))
+	if err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+	if response.Msg.GetFareDifference() != 10 {
+		t.Fatalf("expected fare difference 10, got %v", response.Msg.GetFareDifference())
+	}
+	receipt := response.Msg.GetReceipt()
+	if receipt.GetTicket().GetDepartureId() != "evening" || receipt.GetTicket().GetPricePaid() != 30 {
+		t.Fatalf("expected evening ticket at 30, got %v", receipt.GetTicket())
+	}
+	if len(receipt.GetPreviousBookingIds()) != 1 || receipt.GetPreviousBookingIds()[0] != bookingID {
+		t.Fatalf("expected booking history [%s], got %v", bookingID, receipt.GetPreviousBookingIds())
+	}
+
+	// Changing route to the cheaper train refunds the difference and extends the history
+	response, err = client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   receipt.GetBookingId(),
+		DepartureId: "lille",
+	}))
+	if err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+	if response.Msg.GetFareDifference() != -15 {
+		t.Fatalf("expected fare difference -15, got %v", response.Msg.GetFareDifference())
+	}
+	if response.Msg.GetReceipt().GetTicket().GetTo() != "Lille" {
+		t.Fatalf("expected ticket to Lille, got %v", response.Msg.GetReceipt().GetTicket())
+	}
+	if got := response.Msg.GetReceipt().GetPreviousBookingIds(); len(got) != 2 || got[0] != bookingID || got[1] != receipt.GetBookingId() {
+		t.Fatalf("expected booking history to keep both previous IDs, got %v", got)
+	}
+
+	// The exchanged booking can no longer be used
+	_, err = client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID}))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected exchanged booking to be unusable, got %v", err)
+	}
+
+	admin, err := client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+	if err != nil {
+		t.Fatalf("ViewAdminDetails failed: %v", err)
+	}
+	if len(admin.Msg.GetAdminView().GetSeats()) != 1 {
+		t.Fatalf("expected only the Lille seat to be taken, got %v", admin.Msg.GetAdminView().GetSeats())
+	}
+}
+
+func TestPurchaseTicketOnDepartedTrain(t *testing.T) {
+	// The morning train has left, the evening one hasn't
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)), server.WithClock(func() time.Time { return time.Now().Add(49 * time.Hour) }))
+	_, err := purchase(client, "jane@example.com", onDeparture("morning"))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected FailedPrecondition buying a seat on a train that has left, got %v", err)
+	}
+	if seats := takenSeats(t, client, "morning"); len(seats) != 0 {
+		t.Fatalf("expected no seat to be taken on the morning train, got %v", seats)
+	}
+	if _, err := purchase(client, "jane@example.com", onDeparture("evening")); err != nil {
+		t.Fatalf("expected a seat on the evening train, got %v", err)
+	}
+}
+
+func TestExchangeTicketFullTrainHasNoSideEffects(t *testing.T) {
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)))
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+	// Fill the evening train
+	for i := 0; i < 20; i++ {
+		purchaseTicket(t, client, fmt.Sprintf("Rider%d", i), "Doe", fmt.Sprintf("rider%d@example.com", i), onDeparture("evening"))
+	}
+
+	_, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   bookingID,
+		DepartureId: "evening",
+	}))
+	if connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+
+	// The original booking is still confirmed on the morning train
+	response, err := client.ViewReceipt(context.Background(), connect.NewRequest(&v1.ViewReceiptRequest{
+		Ticket: &v1.Ticket{User: &v1.User{FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"}},
+	}))
+	if err != nil {
+		t.Fatalf("ViewReceipt failed: %v", err)
+	}
+	receipt := response.Msg.GetReceipt()
+	if receipt.GetBookingId() != bookingID || receipt.GetTicket().GetDepartureId() != "morning" || receipt.GetTicket().GetSeat().GetUser() == nil {
+		t.Fatalf("expected original booking to be untouched, got %v", receipt)
+	}
+}
+
+func TestExchangeTicketRefundsOnlyOnceRecorded(t *testing.T) {
+	provider := &server.FakePaymentProvider{}
+	ledger := &failingLedger{fail: func(event *v1.LedgerEvent) bool { return event.GetBookingExchanged() != nil }}
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)), server.WithLedger(ledger), server.WithPaymentProvider(provider))
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+	// The cheaper fare isn't refunded when the exchange can't be recorded
+	_, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   bookingID,
+		DepartureId: "lille",
+	}))
+	if connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	if payments := provider.Payments(); len(payments) != 1 || payments[0].Refunded != 0 {
+		t.Fatalf("expected nothing to be refunded, got %+v", payments)
+	}
+
+	ledger.fail = func(*v1.LedgerEvent) bool { return false }
+	response, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   bookingID,
+		DepartureId: "lille",
+	}))
+	if err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+	if payments := provider.Payments(); payments[0].Refunded != 5 || response.Msg.GetFareDifference() != -5 {
+		t.Fatalf("expected the fare difference to be refunded once, got %+v", payments)
+	}
+}
+
+func TestBookingChangesNeedTheirAccount(t *testing.T) {
+	_, srv := startServer(t, testDepartures(time.Now().Add(48*time.Hour)), server.WithAuthenticator(server.JWT(testJWTKey)))
+	owner := newClient(srv, signJWT(t, "agent-7", "agent"))
+	other := newClient(srv, signJWT(t, "agent-8", "agent"))
+	bookingID := purchaseTicket(t, owner, "Jane", "Roe", "jane@example.com")
+
+	_, err := other.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{BookingId: bookingID, DepartureId: "evening"}))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied exchanging another account's booking, got %v", err)
+	}
+	_, err = other.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID}))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied cancelling another account's booking, got %v", err)
+	}
+
+	// The booking's own account may exchange it, and the new booking stays
+	// theirs
+	exchange, err := owner.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{BookingId: bookingID, DepartureId: "evening"}))
+	if err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+	exchangedID := exchange.Msg.GetReceipt().GetBookingId()
+	_, err = other.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: exchangedID}))
+	if connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied cancelling another account's exchanged booking, got %v", err)
+	}
+
+	// Admins may cancel anyone's booking
+	admin := newClient(srv, signJWT(t, "alice", "admin"))
+	if _, err := admin.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: exchangedID})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+}
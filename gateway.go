@@ -0,0 +1,337 @@
+package ticketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// REST_PATH_PREFIX is the path the REST routes of the google.api.http options
+// are served under.
+const REST_PATH_PREFIX = "/v1/"
+
+// REST_MAX_BODY_BYTES bounds the JSON body of a REST request.
+const REST_MAX_BODY_BYTES = 1 << 20
+
+// restRoute is a REST route of an RPC, as given by its google.api.http option.
+type restRoute struct {
+	verb       string   // HTTP method
+	template   string   // Path template, such as /v1/bookings/{booking_id}:exchange
+	segments   []string // Path segments, with variables written as {field}
+	customVerb string   // The part after the colon of the last segment, if any
+	body       string   // "*" for the whole request, or the field the body is read into
+	procedure  string
+	method     protoreflect.MethodDescriptor
+}
+
+// restRoutes reads the routes of every RPC with a google.api.http option,
+// including its additional bindings.
+var restRoutes = sync.OnceValue(func() []restRoute {
+	service := v1.File_proto_train_ticketing_v1_ticketing_proto.Services().ByName("TrainTicketingService")
+	var routes []restRoute
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, _ := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if rule == nil || method.IsStreamingClient() || method.IsStreamingServer() {
+			continue
+		}
+		for _, binding := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			route := restRoute{
+				body:      binding.GetBody(),
+				procedure: "/" + string(service.FullName()) + "/" + string(method.Name()),
+				method:    method,
+			}
+			switch pattern := binding.GetPattern().(type) {
+			case *annotations.HttpRule_Get:
+				route.verb, route.template = http.MethodGet, pattern.Get
+			case *annotations.HttpRule_Post:
+				route.verb, route.template = http.MethodPost, pattern.Post
+			case *annotations.HttpRule_Put:
+				route.verb, route.template = http.MethodPut, pattern.Put
+			case *annotations.HttpRule_Patch:
+				route.verb, route.template = http.MethodPatch, pattern.Patch
+			case *annotations.HttpRule_Delete:
+				route.verb, route.template = http.MethodDelete, pattern.Delete
+			case *annotations.HttpRule_Custom:
+				route.verb, route.template = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+			}
+			route.segments, route.customVerb = splitRESTPath(route.template)
+			for i, segment := range route.segments {
+				// Variables match a single segment, so {name=*} is just {name}
+				if name, ok := restVariable(segment); ok {
+					name, _, _ = strings.Cut(name, "=")
+					route.segments[i] = "{" + name + "}"
+				}
+			}
+			routes = append(routes, route)
+		}
+	}
+	return routes
+})
+
+// splitRESTPath splits a path into its segments and the custom verb after the
+// colon of the last one, as in /v1/users:remove.
+func splitRESTPath(path string) ([]string, string) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	last := segments[len(segments)-1]
+	if i := strings.LastIndex(last, ":"); i >= 0 && !strings.Contains(last[i:], "}") {
+		segments[len(segments)-1] = last[:i]
+		return segments, last[i+1:]
+	}
+	return segments, ""
+}
+
+// restVariable returns the field path of a {field} segment.
+func restVariable(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// match reports whether path is one of the route's, and the values of its
+// variables if it is.
+func (r *restRoute) match(path string) (map[string]string, bool) {
+	segments, customVerb := splitRESTPath(path)
+	if customVerb != r.customVerb || len(segments) != len(r.segments) {
+		return nil, false
+	}
+	vars := make(map[string]string)
+	for i, segment := range segments {
+		if name, ok := restVariable(r.segments[i]); ok && segment != "" {
+			vars[name] = segment
+		} else if segment != r.segments[i] {
+			return nil, false
+		}
+	}
+	return vars, true
+}
+
+// newRESTGateway serves the REST routes by turning each request into a
+// Connect call with a JSON body for next to serve, so REST callers are
+// authenticated, limited, audited and answered like any other. Errors come
+// back as Connect's JSON errors, with the matching HTTP status.
+func newRESTGateway(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		for _, route := range restRoutes() {
+			vars, ok := route.match(r.URL.Path)
+			if !ok {
+				continue
+			}
+			if route.verb != r.Method {
+				allowed = append(allowed, route.verb)
+				continue
+			}
+
+			msg, err := route.request(r, vars)
+			if err != nil {
+				writeRESTError(w, http.StatusBadRequest, connect.CodeInvalidArgument, err)
+				return
+			}
+			body, err := protojson.Marshal(msg)
+			if err != nil {
+				writeRESTError(w, http.StatusInternalServerError, connect.CodeInternal, err)
+				return
+			}
+			call := r.Clone(context.WithValue(r.Context(), restRouteKey{}, route.template))
+			call.Method = http.MethodPost
+			call.URL.Path, call.URL.RawPath, call.URL.RawQuery = route.procedure, "", ""
+			call.Body = io.NopCloser(bytes.NewReader(body))
+			call.ContentLength = int64(len(body))
+			call.Header.Del("Content-Encoding")
+			call.Header.Set("Content-Type", "application/json")
+			call.Header.Set("Connect-Protocol-Version", "1")
+			next.ServeHTTP(w, call)
+			return
+		}
+
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			writeRESTError(w, http.StatusMethodNotAllowed, connect.CodeUnimplemented, fmt.Errorf("%s is not allowed on %s", r.Method, r.URL.Path))
+			return
+		}
+		writeRESTError(w, http.StatusNotFound, connect.CodeNotFound, fmt.Errorf("no route for %s", r.URL.Path))
+	})
+}
+
+// request builds the RPC's request from the body, the path's variables and,
+// unless the body holds the whole request, the query string.
+func (r *restRoute) request(req *http.Request, vars map[string]string) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(r.method.Input().FullName())
+	if err != nil {
+		return nil, err
+	}
+	msg := messageType.New()
+
+	if r.body != "" {
+		data, err := io.ReadAll(io.LimitReader(req.Body, REST_MAX_BODY_BYTES+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > REST_MAX_BODY_BYTES {
+			return nil, fmt.Errorf("request body is larger than %d bytes", REST_MAX_BODY_BYTES)
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			target := msg
+			if r.body != "*" {
+				field := msg.Descriptor().Fields().ByName(protoreflect.Name(r.body))
+				target = msg.Mutable(field).Message()
+			}
+			if err := protojson.Unmarshal(data, target.Interface()); err != nil {
+				return nil, fmt.Errorf("invalid request body: %w", err)
+			}
+		}
+	}
+
+	// Set the fields in a fixed order, so a repeated field's values keep the
+	// order they were given in
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := setRESTField(msg, name, vars[name]); err != nil {
+			return nil, err
+		}
+	}
+	if r.body != "*" {
+		query := req.URL.Query()
+		names = names[:0]
+		for name := range query {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, value := range query[name] {
+				if err := setRESTField(msg, name, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return msg.Interface(), nil
+}
+
+// setRESTField sets the field at a dotted path of proto or JSON field names,
+// such as ticket.user.email, to a value given in a path or query string.
+// Repeated fields have the value appended.
+func setRESTField(msg protoreflect.Message, path, value string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fields := msg.Descriptor().Fields()
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil {
+			field = fields.ByJSONName(name)
+		}
+		if field == nil {
+			return fmt.Errorf("unknown field %q", path)
+		}
+		if i < len(names)-1 {
+			if field.Message() == nil || field.IsList() || field.IsMap() {
+				return fmt.Errorf("field %q has no fields", strings.Join(names[:i+1], "."))
+			}
+			msg = msg.Mutable(field).Message()
+			continue
+		}
+
+		if field.IsMap() {
+			return fmt.Errorf("map field %q can't be set from a string", path)
+		}
+		var element protoreflect.Message
+		if field.Message() != nil {
+			if field.IsList() {
+				element = msg.Mutable(field).List().NewElement().Message()
+			} else {
+				element = msg.NewField(field).Message()
+			}
+		}
+		v, err := parseRESTValue(field, element, value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", value, path, err)
+		}
+		if field.IsList() {
+			msg.Mutable(field).List().Append(v)
+		} else {
+			msg.Set(field, v)
+		}
+	}
+	return nil
+}
+
+// parseRESTValue reads a field's value from a string. Messages, such as
+// timestamps, are read as if the string were their JSON into element.
+func parseRESTValue(field protoreflect.FieldDescriptor, element protoreflect.Message, value string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(value)
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(value)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("unknown enum value")
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		quoted, _ := json.Marshal(value)
+		if err := protojson.Unmarshal(quoted, element.Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfMessage(element), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", field.Kind())
+}
+
+// writeRESTError writes an error the gateway found itself the way Connect
+// writes the errors of RPCs.
+func writeRESTError(w http.ResponseWriter, status int, code connect.Code, err error) {
+	body, _ := json.Marshal(map[string]string{"code": code.String(), "message": err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,214 @@
+package ticketing_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	server "github.com/parandor/ticketing"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// restCall makes a REST call with a JSON body, if any, and returns the status
+// and body of the response.
+func restCall(t *testing.T, srv *httptest.Server, method, path, body, token string) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("reading %s %s failed: %v", method, path, err)
+	}
+	return res.StatusCode, data
+}
+
+// restMessage makes a REST call that must succeed and reads its response into
+// msg.
+func restMessage(t *testing.T, srv *httptest.Server, method, path, body string, msg proto.Message) {
+	t.Helper()
+	status, data := restCall(t, srv, method, path, body, server.DEMO_AUTH_TOKEN)
+	if status != http.StatusOK {
+		t.Fatalf("%s %s: expected 200, got %d: %s", method, path, status, data)
+	}
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		t.Fatalf("reading %s %s failed: %v: %s", method, path, err, data)
+	}
+}
+
+// restError makes a REST call that must fail and returns the code of its
+// JSON error.
+func restError(t *testing.T, srv *httptest.Server, method, path, body string, want int) string {
+	t.Helper()
+	status, data := restCall(t, srv, method, path, body, server.DEMO_AUTH_TOKEN)
+	var connectErr struct{ Code string }
+	if err := json.Unmarshal(data, &connectErr); status != want || err != nil {
+		t.Fatalf("%s %s: expected a JSON error with status %d, got %d: %s", method, path, want, status, data)
+	}
+	return connectErr.Code
+}
+
+func TestRESTGateway(t *testing.T) {
+	_, srv := startServer(t)
+
+	var purchased v1.PurchaseTicketResponse
+	restMessage(t, srv, http.MethodPost, "/v1/tickets",
+		`{"ticket": {"user": {"firstName": "Jane", "lastName": "Roe", "email": "jane@example.com"}, "seat": {"seatNumber": 7}}}`, &purchased)
+	receipt := purchased.GetReceipt()
+	if receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED || receipt.GetTicket().GetSeat().GetSeatNumber() != 7 {
+		t.Fatalf("expected a confirmed booking of seat 7, got %v", receipt)
+	}
+
+	// Receipts can be found by booking ID in the path, or by passenger in the
+	// query string
+	var viewed v1.ViewReceiptResponse
+	restMessage(t, srv, http.MethodGet, "/v1/receipts/"+receipt.GetBookingId(), "", &viewed)
+	if viewed.GetReceipt().GetBookingId() != receipt.GetBookingId() {
+		t.Fatalf("expected the receipt of %s, got %v", receipt.GetBookingId(), viewed.GetReceipt())
+	}
+	restMessage(t, srv, http.MethodGet, "/v1/receipts?ticket.user.firstName=Jane&ticket.user.last_name=Roe&ticket.user.email=jane@example.com", "", &viewed)
+	if viewed.GetReceipt().GetBookingId() != receipt.GetBookingId() {
+		t.Fatalf("expected the receipt of %s, got %v", receipt.GetBookingId(), viewed.GetReceipt())
+	}
+
+	var seatMap v1.ViewSeatMapResponse
+	restMessage(t, srv, http.MethodGet, "/v1/departures/"+server.DEFAULT_DEPARTURE_ID+"/seats", "", &seatMap)
+	if seats := seatMap.GetSeats(); len(seats) < 7 || !seats[6].GetTaken() {
+		t.Fatalf("expected seat 7 to be taken, got %v", seats)
+	}
+
+	// Errors carry their Connect code and matching status
+	if code := restError(t, srv, http.MethodDelete, "/v1/bookings/"+receipt.GetBookingId()+"?expectedVersion=99", "", http.StatusConflict); code != "aborted" {
+		t.Fatalf("expected aborted cancelling an old version, got %q", code)
+	}
+	if code := restError(t, srv, http.MethodDelete, "/v1/bookings/"+receipt.GetBookingId()+"?expectedVersion=soon", "", http.StatusBadRequest); code != "invalid_argument" {
+		t.Fatalf("expected invalid_argument for a bad query value, got %q", code)
+	}
+	if code := restError(t, srv, http.MethodGet, "/v1/receipts/missing", "", http.StatusNotFound); code != "not_found" {
+		t.Fatalf("expected not_found for a missing booking, got %q", code)
+	}
+
+	var cancelled v1.CancelBookingResponse
+	restMessage(t, srv, http.MethodDelete, "/v1/bookings/"+receipt.GetBookingId(), "", &cancelled)
+	if cancelled.GetCancellationReceipt().GetBookingId() != receipt.GetBookingId() {
+		t.Fatalf("expected the booking to be cancelled, got %v", cancelled.GetCancellationReceipt())
+	}
+
+	// REST calls need a token like any other
+	if status, _ := restCall(t, srv, http.MethodGet, "/v1/seats", "", ""); status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", status)
+	}
+	if code := restError(t, srv, http.MethodPut, "/v1/tickets", "{}", http.StatusMethodNotAllowed); code != "unimplemented" {
+		t.Fatalf("expected unimplemented for the wrong method, got %q", code)
+	}
+	if code := restError(t, srv, http.MethodGet, "/v1/trains", "", http.StatusNotFound); code != "not_found" {
+		t.Fatalf("expected not_found for an unknown route, got %q", code)
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	_, srv := startServer(t)
+
+	// The document doesn't need a token
+	status, data := restCall(t, srv, http.MethodGet, server.OPENAPI_PATH, "", "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, data)
+	}
+	var document struct {
+		OpenAPI string
+		Paths   map[string]map[string]struct {
+			OperationID string
+			Parameters  []struct{ Name, In string }
+			RequestBody *struct{}
+		}
+		Components struct{ Schemas map[string]json.RawMessage }
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("reading the document failed: %v", err)
+	}
+	if !strings.HasPrefix(document.OpenAPI, "3.") {
+		t.Fatalf("expected an OpenAPI 3 document, got %q", document.OpenAPI)
+	}
+
+	for _, tc := range []struct {
+		path, method, operationID string
+		body                      bool
+		parameters                []string
+	}{
+		{"/v1/tickets", "post", "PurchaseTicket", true, nil},
+		{"/v1/receipts/{booking_id}", "get", "ViewReceipt", false, []string{"path booking_id", "query ticket.user.email"}},
+		{"/v1/bookings/{booking_id}", "delete", "CancelBooking", false, []string{"path booking_id", "query expectedVersion"}},
+		{"/v1/bookings/{booking_id}:exchange", "post", "ExchangeTicket", true, []string{"path booking_id"}},
+	} {
+		operation, ok := document.Paths[tc.path][tc.method]
+		if !ok {
+			t.Fatalf("expected %s %s in the document", tc.method, tc.path)
+		}
+		if operation.OperationID != tc.operationID || (operation.RequestBody != nil) != tc.body {
+			t.Fatalf("%s %s: expected %s with body %v, got %+v", tc.method, tc.path, tc.operationID, tc.body, operation)
+		}
+		var parameters []string
+		for _, parameter := range operation.Parameters {
+			parameters = append(parameters, parameter.In+" "+parameter.Name)
+		}
+		for _, want := range tc.parameters {
+			if !strings.Contains(strings.Join(parameters, ","), want) {
+				t.Fatalf("%s %s: expected parameter %q, got %v", tc.method, tc.path, want, parameters)
+			}
+		}
+	}
+
+	// Every schema the document refers to is in it
+	for _, ref := range schemaRefs(data) {
+		if _, ok := document.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]; !ok {
+			t.Fatalf("expected the document to have %s", ref)
+		}
+	}
+	if _, ok := document.Components.Schemas["proto.train_ticketing.v1.Receipt"]; !ok {
+		t.Fatal("expected the document to describe receipts")
+	}
+}
+
+// schemaRefs returns every $ref in a JSON document.
+func schemaRefs(data []byte) []string {
+	var refs []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if ref, ok := value.(string); ok && key == "$ref" {
+					refs = append(refs, ref)
+				}
+				walk(value)
+			}
+		case []any:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	var document any
+	json.Unmarshal(data, &document)
+	walk(document)
+	return refs
+}
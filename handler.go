@@ -2,69 +2,234 @@ package ticketing
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	connect "connectrpc.com/connect"
 	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
 	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const SEAT_COST = 20
 // MyTrainTicketingServiceHandler is an implementation of the TrainTicketingServiceHandler interface.
 type MyTrainTicketingServiceHandler struct {
 	users map[string]*v1.User // Map to store users by ID
-	seats map[string]*v1.Seat // Map to store seats by ID
+	departures map[string]*departure // Map to store departures and their seats by ID
+	bookings map[string]*booking // Map to store bookings by booking ID
+	passengers *passengerIndex // Confirmed bookings by passenger and seat
 	DiscounCodes map[string]string
-	mu    sync.Mutex          // Mutex to ensure safe access to the maps
+	mu    sync.RWMutex        // Guards the maps, only held exclusively while an event is applied
 	SeatCost float64
+
+	schedule           []*v1.Departure
+	defaultDepartureID string
+	departureTime      time.Time
+	cancellationRules  CancellationRules
+	paymentProvider    PaymentProvider
+	paymentTimeout     time.Duration
+	idempotency        *idempotencyStore
+	ledger             Ledger
+	ledgerMu           sync.Mutex     // Keeps ledger events in sequence, taken before mu
+	sequence           int64          // Sequence number of the last ledger event applied
+	snapshotInterval   int
+	snapshotSequence   int64          // Sequence number of the last event in the latest snapshot
+	redemptions        map[string]int // Number of times each discount code was used
+	cluster            *cluster       // Members sharing out the departures, if any
+	clusterHTTP        connect.HTTPClient
+	clusterSecret      string
+	authenticate       Authenticator
+	interceptors       []connect.Interceptor // Run before the handler's own interceptors
+	draining           atomic.Bool           // Set once the server is shutting down
+	metrics            *metrics              // Nil unless WithMetrics is used
+	audit              *auditor
+	rateLimiter        *rateLimiter // Nil unless WithRateLimits is used
+	scalpingRules      ScalpingRules
+	tracer             trace.Tracer
+	now                func() time.Time
+}
+
+// booking tracks a single purchase from the moment its seat is held until it
+// is cancelled or exchanged.
+type booking struct {
+	id            string
+	seat          *v1.Seat
+	ticket        *v1.Ticket
+	status        v1.BookingStatus
+	purchasedAt   time.Time
+	cancelledAt   time.Time
+	refundAmount  float32
+	previousIDs   []string // Bookings this one replaced through exchanges, oldest first
+	paymentMethod *v1.PaymentMethod
+	payments      []*v1.Payment // Captured payments that can still be refunded
+	version       int64         // Incremented on every change so concurrent edits can be detected
+	account       string        // Subject of the purchaser's JWT, if they sent one
+	clientIP      string
+	fingerprint   string        // Identifies the payment method, see paymentFingerprint
+	reviewReason  string        // Why the purchase was held for review, if it was
+}
+
+// Option configures a MyTrainTicketingServiceHandler.
+type Option func(*MyTrainTicketingServiceHandler)
+
+// WithClock overrides the clock used to timestamp bookings and evaluate
+// cancellation rules.
+func WithClock(now func() time.Time) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.now = now
+	}
+}
+
+// WithDepartureTime sets when the default London to France train leaves. It
+// defaults to one week after the handler is created and is ignored when
+// WithDepartures is used.
+func WithDepartureTime(departure time.Time) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.departureTime = departure
+	}
+}
+
+// WithInterceptors adds interceptors that run around every RPC, before the
+// handler's own interceptors.
+func WithInterceptors(interceptors ...connect.Interceptor) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.interceptors = append(h.interceptors, interceptors...)
+	}
+}
+
+// NewMyTicketingServiceHandler creates a handler and returns the path and HTTP
+// handler to serve it on. It panics if the ledger can't be replayed; use New
+// to handle that error instead.
+func NewMyTicketingServiceHandler(opts ...Option) (string, http.Handler) {
+	handler, err := New(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return handler.Handler()
 }
 
-func NewMyTicketingServiceHandler() (string, http.Handler) {
+// New creates a handler configured by opts and replays its ledger to restore
+// the bookings made before a restart. Seats still held for purchases that never
+// completed are released, unless the ledger is shared with other handlers.
+func New(opts ...Option) (*MyTrainTicketingServiceHandler, error) {
 	handler := &MyTrainTicketingServiceHandler{
 		users: make(map[string]*v1.User),
-		seats: make(map[string]*v1.Seat),
+		departures: make(map[string]*departure),
+		bookings: make(map[string]*booking),
+		passengers: newPassengerIndex(),
 		DiscounCodes: make(map[string]string),
 		SeatCost: SEAT_COST,
+		cancellationRules: DefaultCancellationRules,
+		paymentProvider:   &FakePaymentProvider{},
+		paymentTimeout:    DEFAULT_PAYMENT_TIMEOUT,
+		idempotency:       newIdempotencyStore(),
+		ledger:            &MemoryLedger{},
+		snapshotInterval:  DEFAULT_SNAPSHOT_INTERVAL,
+		redemptions:       make(map[string]int),
+		clusterHTTP:       http.DefaultClient,
+		authenticate:      BearerToken(DEMO_AUTH_TOKEN),
+		audit:             &auditor{log: &MemoryAuditLog{}},
+		now:               time.Now,
+	}
+	for _, opt := range opts {
+		opt(handler)
+	}
+	handler.idempotency.now = handler.now
+	handler.audit.now = handler.now
+	if handler.rateLimiter != nil {
+		handler.rateLimiter.now = handler.now
+	}
+	if handler.tracer == nil {
+		handler.tracer = otel.GetTracerProvider().Tracer(TRACER_NAME)
+	}
+	if handler.cluster != nil {
+		if handler.clusterSecret == "" {
+			return nil, errors.New("cluster members must share a secret set with WithClusterSecret")
+		}
+		handler.cluster.http = handler.clusterHTTP
+		handler.cluster.secret = handler.clusterSecret
 	}
 
-	for i := 1; i <= 20; i++ {
-		seatID := fmt.Sprintf("%d", i)
-		seat := &v1.Seat{SeatNumber: 0}
-		handler.seats[seatID] = seat
+	if err := handler.setupDepartures(); err != nil {
+		return nil, err
 	}
 
 	handler.DiscounCodes["TBD123"] = "1"
 	handler.DiscounCodes["WOW1"] = "2"
 	handler.DiscounCodes["Test3"] = "5"
 
+	// Rebuild the bookings from the latest snapshot and the events recorded since
+	if err := handler.recoverState(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to replay ledger: %w", err)
+	}
+	if err := handler.audit.recover(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return handler, nil
+}
+
+// Handler returns the path and HTTP handler that serve h.
+func (h *MyTrainTicketingServiceHandler) Handler() (string, http.Handler) {
+	// Send requests to the cluster members that serve them before anything
+	// else, so the member that does the work also remembers idempotency keys
+	// and audits the changes it makes
+	interceptors := []connect.Interceptor{h.audit, tracingInterceptor{}, h.idempotency.Interceptor()}
+	if h.cluster != nil {
+		interceptors = append([]connect.Interceptor{h.cluster.Interceptor(h)}, interceptors...)
+	}
+	if h.rateLimiter != nil {
+		interceptors = append([]connect.Interceptor{h.rateLimiter}, interceptors...)
+	}
+	if h.metrics != nil {
+		interceptors = append([]connect.Interceptor{h.metrics}, interceptors...)
+	}
+	interceptors = append(append([]connect.Interceptor(nil), h.interceptors...), interceptors...)
+
 	// Use NewTicketingServiceHandler to create the HTTP handler
-	path, httpHandler := ticketingv1.NewTrainTicketingServiceHandler(handler)
+	path, httpHandler := ticketingv1.NewTrainTicketingServiceHandler(h,
+		connect.WithInterceptors(interceptors...),
+	)
 
 	// Apply middleware to intercept JWT tokens
-	httpHandler = withJWTInterceptor(httpHandler)
+	httpHandler = withJWTInterceptor(httpHandler, h.traceAuthentication(h.authenticate))
+
+	// Only cluster members may say a request was forwarded
+	httpHandler = h.withPeerHeaders(httpHandler)
+
+	// Start the span of each request before anything else
+	httpHandler = h.withTracing(httpHandler)
 
 	// Optionally, you can add middleware or modify the http.Handler here
 
 	return path, httpHandler
 }
 
-func withJWTInterceptor(next http.Handler) http.Handler {
+func withJWTInterceptor(next http.Handler, authenticate Authenticator) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Your JWT token verification logic here
-		// For example, you can check the "Authorization" header for the token
-		token := r.Header.Get("Authorization")
-
-		// This is for demo purposes only. Never do this in production!!!
-		if token != "Bearer auth_token" {
-			http.Error(w, "Unauthorized: No JWT token provided", http.StatusUnauthorized)
+		// Check the credentials in the "Authorization" header. Unless another
+		// Authenticator is configured, this only accepts the demo token
+		identity, err := authenticate(r)
+		if err != nil {
+			http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		// If the token is valid, you can proceed with the next handler
+		// If the token is valid, you can proceed with the next handler,
+		// telling it who sent the request when the token says so
+		if identity != nil {
+			r = r.WithContext(context.WithValue(r.Context(), identityKey{}, identity))
+		}
 		next.ServeHTTP(w, r)
 	})
 }
@@ -96,51 +261,48 @@ func (h *MyTrainTicketingServiceHandler) PurchaseTicket(ctx context.Context, req
 	// Extract ticket information from the request
 	user := ticket.GetUser()
 
-	// Lock the mutex to ensure safe access to the maps
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	// Validate user
 	if user == nil || user.GetFirstName() == "" || user.GetLastName() == "" || user.GetEmail() == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user information is invalid"))
 	}
 
-	var availableSeatID string
-	for seatID, seat := range h.seats {
-		if seat.GetSeatNumber() == 0 {
-			availableSeatID = seatID
-			break
-		}
-	}
-
-	// Check if a seat is available
-	if availableSeatID == "" {
-		return nil, connect.NewError(connect.CodeResourceExhausted, errors.New("no available seats"))
+	// Hold a seat while the payment goes through
+	p := newPurchaser(ctx, req.Peer(), req.Header(), req.Msg.GetPaymentMethod())
+	b, err := h.holdSeat(ctx, ticket, req.Msg.GetPaymentMethod(), p)
+	if err != nil {
+		return nil, err
 	}
 
-	seatNumber, err := strconv.Atoi(availableSeatID)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to parse available seat ID"))
+	// Purchases that look like scalping aren't paid for until an admin
+	// approves them
+	if b.reviewReason != "" {
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+		return connect.NewResponse(&v1.PurchaseTicketResponse{Receipt: b.receipt()}), nil
 	}
 
-	// Assign the seat to the user
-	assignedSeat := h.seats[availableSeatID]
-	assignedSeat.SeatNumber = int32(seatNumber)
-	assignedSeat.User = user // Associate the user with the seat
+	// Take payment without holding the lock so other purchases aren't blocked
+	payments, err := h.charge(ctx, b.paymentMethod, b.ticket.GetPricePaid())
 
-	h.users[user.Email] = user
+	// Lock the maps for reading again; recording the outcome only locks them
+	// for writing while it is applied
+	h.mu.RLock()
+	defer h.mu.RUnlock()
 
-	discount, err := h.GetDiscount(req.Msg.Ticket.DiscountCode)
+	// Give the seat back if the payment didn't go through
 	if err != nil {
-		newCost := h.SeatCost - discount 
-		ticket.PricePaid = float32(newCost)
-	} 
+		return nil, h.releaseHold(ctx, b, err)
+	}
 
-	// Generate a receipt
-	receipt := &v1.Receipt{
-		Ticket: ticket,
+	// Confirm the booking, handing the money back if that can't be recorded
+	if err := h.confirm(ctx, b, payments); err != nil {
+		_, _ = h.refund(context.WithoutCancel(ctx), payments, b.ticket.GetPricePaid())
+		return nil, h.releaseHold(ctx, b, err)
 	}
 
+	// Generate a receipt
+	receipt := b.receipt()
+
 	// Return the response containing the receipt
 	response := &v1.PurchaseTicketResponse{
 		Receipt: receipt,
@@ -149,17 +311,152 @@ func (h *MyTrainTicketingServiceHandler) PurchaseTicket(ctx context.Context, req
 	return connect.NewResponse(response), nil
 }
 
+// holdSeat reserves a seat for the ticket's user and records a held booking
+// that is confirmed once payment succeeds, or once an admin approves it when
+// the scalping rules hold it for review.
+func (h *MyTrainTicketingServiceHandler) holdSeat(ctx context.Context, requested *v1.Ticket, method *v1.PaymentMethod, p purchaser) (*booking, error) {
+	d, err := h.lookupDeparture(requested.GetDepartureId())
+	if err != nil {
+		return nil, err
+	}
+	if !h.owns(d.info.GetId()) {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("departure %q is served by another cluster member", d.info.GetId()))
+	}
+	if d.departed(h.now()) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("train has already departed"))
+	}
+
+	// Lock the departure, so no exchange is paying for a seat this purchase
+	// could take, and then the maps for reading. Purchases on other
+	// departures go ahead at the same time
+	defer h.lockDepartures(d.info.GetId())()
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	defer h.mu.RUnlock()
+
+	bookingID := newBookingID()
+	for attempt := 1; ; attempt++ {
+		// Turn away or hold back purchases that look like scalping
+		reviewReason, err := h.screen(d, requested.GetUser(), p)
+		if err != nil {
+			return nil, err
+		}
+
+		// Take the requested seat, or check if any seat is available
+		var assignedSeat *v1.Seat
+		if number := requested.GetSeat().GetSeatNumber(); number != 0 {
+			assignedSeat = d.seat(number)
+			if assignedSeat == nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("requested seat does not exist"))
+			}
+			if assignedSeat.GetUser() != nil {
+				return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("requested seat is already taken"))
+			}
+		} else {
+			assignedSeat, err = h.allocateSeat(ctx, d, v1.Section_SECTION_TYPE_UNSPECIFIED, bookingID)
+			if err != nil {
+				return nil, err
+			}
+			if assignedSeat == nil {
+				return nil, connect.NewError(connect.CodeResourceExhausted, errNoSeats)
+			}
+		}
+
+		// The route and price always come from the departure, not from the request
+		ticket := &v1.Ticket{
+			From:          d.info.GetFrom(),
+			To:            d.info.GetTo(),
+			User:          requested.GetUser(),
+			PricePaid:     h.price(ctx, d, requested.GetDiscountCode()),
+			Seat:          &v1.Seat{SeatNumber: assignedSeat.GetSeatNumber()},
+			DiscountCode:  requested.GetDiscountCode(),
+			DepartureTime: d.info.GetDepartureTime(),
+			DepartureId:   d.info.GetId(),
+		}
+
+		// Record the booking so it can be confirmed, and later cancelled. If
+		// another handler sharing the ledger got in first, look for a seat again
+		err = h.record(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_SeatHeld{SeatHeld: &v1.SeatHeld{
+			BookingId:          bookingID,
+			Ticket:             ticket,
+			PaymentMethod:      method,
+			Account:            p.account,
+			ClientIp:           p.clientIP,
+			PaymentFingerprint: p.paymentFingerprint,
+			ReviewReason:       reviewReason,
+		}}})
+		if errors.Is(err, ErrLedgerConflict) && attempt < LEDGER_CONFLICT_RETRIES {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return h.bookings[bookingID], nil
+	}
+}
+
+// releaseHold gives back the seat of a held booking whose purchase failed
+// with err, and returns err along with any failure to release it. The release
+// is recorded even if the caller has gone away, so the seat isn't left held.
+// The caller must hold h.mu for reading.
+func (h *MyTrainTicketingServiceHandler) releaseHold(ctx context.Context, b *booking, err error) error {
+	release := &v1.LedgerEvent{Event: &v1.LedgerEvent_HoldReleased{HoldReleased: &v1.HoldReleased{BookingId: b.id}}}
+	releaseErr := h.recordOwn(context.WithoutCancel(ctx), release)
+	if releaseErr == nil {
+		return err
+	}
+	message := err.Error()
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		message = connectErr.Message()
+	}
+	return connect.NewError(connect.CodeOf(err), fmt.Errorf("%s, and failed to release the seat held by booking %s: %w", message, b.id, releaseErr))
+}
+
+// confirm records that a held booking has been paid for, along with the
+// discount it used. The caller must hold h.mu for reading.
+func (h *MyTrainTicketingServiceHandler) confirm(ctx context.Context, b *booking, payments []*v1.Payment) error {
+	fare := h.departures[b.ticket.GetDepartureId()].info.GetFare()
+	if discount := fare - b.ticket.GetPricePaid(); discount > 0 {
+		err := h.recordOwn(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_DiscountRedeemed{DiscountRedeemed: &v1.DiscountRedeemed{
+			BookingId:    b.id,
+			DiscountCode: b.ticket.GetDiscountCode(),
+			Amount:       discount,
+		}}})
+		if err != nil {
+			return err
+		}
+	}
+	return h.recordOwn(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_BookingConfirmed{BookingConfirmed: &v1.BookingConfirmed{
+		BookingId: b.id,
+		Payments:  payments,
+	}}})
+}
+
 // ViewReceipt implements the ViewReceipt method of TrainTicketingServiceHandler.
 func (h *MyTrainTicketingServiceHandler) ViewReceipt(ctx context.Context, req *connect.Request[v1.ViewReceiptRequest]) (*connect.Response[v1.ViewReceiptResponse], error) {
 	// Extract the ViewReceiptRequest parameters from the req
 	ticket := req.Msg.GetTicket()
 
-	// Lock the mutex to ensure safe access to the maps
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	// Retrieve the receipt for the provided ticket
-	receipt, err := h.retrieveReceipt(ticket)
+	// Take a read lock so other reads can go ahead at the same time
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	defer h.mu.RUnlock()
+
+	// Retrieve the receipt of the booking, or for the provided ticket
+	var receipt *v1.Receipt
+	var err error
+	if id := req.Msg.GetBookingId(); id != "" {
+		if b, ok := h.bookings[id]; ok {
+			receipt = b.receipt()
+		} else {
+			err = errors.New("receipt not found")
+		}
+	} else {
+		receipt, err = h.retrieveReceipt(ticket)
+	}
 	if err != nil {
 		return nil, connect.NewError(connect.CodeNotFound, err) // Assuming receipt not found is treated as a not found error
 	}
@@ -174,18 +471,17 @@ func (h *MyTrainTicketingServiceHandler) ViewReceipt(ctx context.Context, req *c
 }
 
 func (h *MyTrainTicketingServiceHandler) retrieveReceipt(ticket *v1.Ticket) (*v1.Receipt, error) {
-	// Iterate over the seats to find the one with the matching ticket information
-	for _, seat := range h.seats {
-		if seat.GetSeatNumber() != 0 && seat.GetUser() != nil {
-			// If the seat is occupied and the user matches the ticket information, return the receipt
-			if seat.GetUser().GetFirstName() == ticket.GetUser().GetFirstName() &&
-				seat.GetUser().GetLastName() == ticket.GetUser().GetLastName() &&
-				seat.GetUser().GetEmail() == ticket.GetUser().GetEmail() {
-				ticket.Seat = seat
-				return &v1.Receipt{
-					Ticket: ticket,
-				}, nil
-			}
+	// Iterate over the confirmed bookings to find the one with the matching ticket information
+	for _, b := range h.bookings {
+		if b.status != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+			continue
+		}
+		user := b.seat.GetUser()
+		// If the seat is occupied and the user matches the ticket information, return the receipt
+		if user.GetFirstName() == ticket.GetUser().GetFirstName() &&
+			user.GetLastName() == ticket.GetUser().GetLastName() &&
+			user.GetEmail() == ticket.GetUser().GetEmail() {
+			return b.receipt(), nil
 		}
 	}
 
@@ -194,122 +490,219 @@ func (h *MyTrainTicketingServiceHandler) retrieveReceipt(ticket *v1.Ticket) (*v1
 }
 
 // ViewAdminDetails implements the ViewAdminDetails method of TrainTicketingServiceHandler.
+// Only admins may view every passenger's details.
 func (h *MyTrainTicketingServiceHandler) ViewAdminDetails(ctx context.Context, req *connect.Request[v1.ViewAdminDetailsRequest]) (*connect.Response[v1.ViewAdminDetailsResponse], error) {
-	// Lock the mutex to ensure safe access to the maps
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	// Initialize slices to store all users and seats
-	var allUsers []*v1.User
-	var allSeats []*v1.Seat
-
-	// Iterate through the seats to collect all users and seats information
-	for _, seat := range h.seats {
-		if seat.GetUser() != nil {
-			allSeats = append(allSeats, seat)
-			allUsers = append(allUsers, seat.GetUser())
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Check the filters and page token
+	query, err := h.newAdminQuery(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take a read lock so other reads can go ahead at the same time
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	defer h.mu.RUnlock()
+
+	// Collect the bookings of every occupied seat, copied as they keep
+	// changing after the lock is released
+	var bookings []*v1.Receipt
+	for _, b := range h.bookings {
+		if b.holdsSeat() {
+			bookings = append(bookings, b.receipt())
 		}
 	}
 
-	// Create a response containing all users and seats information
-	adminView := &v1.AdminView{
-		Users: allUsers,
-		Seats: allSeats,
+	// Create a response containing the requested page of users and seats
+	adminView, nextPageToken, err := query.page(bookings)
+	if err != nil {
+		return nil, err
 	}
 
 	// Return the response
 	response := &v1.ViewAdminDetailsResponse{
-		AdminView: adminView,
+		AdminView:     adminView,
+		NextPageToken: nextPageToken,
 	}
 
 	return connect.NewResponse(response), nil
 }
 
 // RemoveUser implements the RemoveUser method of TrainTicketingServiceHandler.
+// Only admins may remove users.
 func (h *MyTrainTicketingServiceHandler) RemoveUser(ctx context.Context, req *connect.Request[v1.RemoveUserRequest]) (*connect.Response[v1.RemoveUserResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
 	// Extract the user's first name to be removed from the request
 	firstName := req.Msg.GetUser().GetFirstName() // Assuming you have a FirstName field in the User message
 
-	// Lock the mutex to ensure safe access to the maps
-	h.mu.Lock()
-	defer h.mu.Unlock()
+	// Lock the departures the user has bookings on, and the maps
+	var found *v1.User
+	userBookings, unlock, err := h.lockBookings(ctx, func() ([]*booking, error) {
+		// Check if the user to be removed exists
+		found = nil
+		for _, user := range h.users {
+			if user.GetFirstName() == firstName {
+				found = user
+				break
+			}
+		}
+		if found == nil {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("user to be removed not found"))
+		}
+
+		var userBookings []*booking
+		for _, b := range h.bookings {
+			if b.status == v1.BookingStatus_BOOKING_STATUS_CONFIRMED && b.seat.GetUser().GetFirstName() == firstName {
+				userBookings = append(userBookings, b)
+			}
+		}
+		return userBookings, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
-	// Check if the user to be removed exists
-	var found bool
-	for _, user := range h.users {
-		if user.GetFirstName() == firstName {
-			found = true
-			break
+	// Make sure nobody changed the user's bookings since the caller last saw them
+	for _, b := range userBookings {
+		if err := b.checkVersion(req.Msg.GetExpectedVersion()); err != nil {
+			return nil, err
 		}
 	}
-	if !found {
-		return nil, connect.NewError(connect.CodeNotFound, errors.New("user to be removed not found"))
+
+	// Cancel the user's bookings, which also frees their seats
+	var receipt *v1.Receipt
+	for _, b := range userBookings {
+		err := h.record(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_BookingCancelled{BookingCancelled: &v1.BookingCancelled{
+			BookingId: b.id,
+			Payments:  b.payments,
+		}}})
+		if err != nil {
+			return nil, err
+		}
+		receipt = b.receipt()
 	}
 
 	// Remove the user
-	delete(h.users, firstName)
-
-	// Remove the association of the user with any seat
-	for _, seat := range h.seats {
-		if seat.GetUser() != nil && seat.GetUser().GetFirstName() == firstName {
-			seat.SeatNumber = 0
-			seat.User = &v1.User{
-				FirstName: "",
-				LastName:  "",
-				Email:     "",
-			}
-		}
+	err = h.record(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_UserRemoved{UserRemoved: &v1.UserRemoved{Email: found.GetEmail()}}})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return a success response
-	response := &v1.RemoveUserResponse{}
+	response := &v1.RemoveUserResponse{Receipt: receipt}
 	return connect.NewResponse(response), nil
 }
 
 // ModifySeat implements the ModifySeat method of TrainTicketingServiceHandler.
+// Only admins may move passengers.
 func (h *MyTrainTicketingServiceHandler) ModifySeat(ctx context.Context, req *connect.Request[v1.ModifySeatRequest]) (*connect.Response[v1.ModifySeatResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
 	// Extract ModifySeatRequest parameters from the request
 	modifyReq := req.Msg
 
 	// Extract user and new seat information from the request
 	user := modifyReq.GetUser()
 
-	// Lock the mutex to ensure safe access to the maps
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	var availableSeatID string
-	for seatID, seat := range h.seats {
-		if seat.GetSeatNumber() == 0 {
-			availableSeatID = seatID
-			break
+	// Find the user's booking by first name, and lock its departure and the maps
+	bookings, unlock, err := h.lockBookings(ctx, func() ([]*booking, error) {
+		for _, candidate := range h.bookings {
+			// Check if admin, for sake of time, replace admin ID check with first name
+			if candidate.status == v1.BookingStatus_BOOKING_STATUS_CONFIRMED && candidate.seat.GetUser().GetFirstName() == user.GetFirstName() {
+				return []*booking{candidate}, nil
+			}
 		}
-	}
-	seatNumber, err := strconv.ParseInt(availableSeatID, 10, 32)
+
+		// If user is not found, return an error
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found in seats"))
+	})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to parse available seat ID"))
+		return nil, err
 	}
+	defer unlock()
+	b := bookings[0]
 
-	// Find the user by first name in the seats map
-	found := false
-	for _, seat := range h.seats {
-		// Check if admin, for sake of time, replace admin ID check with first name
-		if seat.User != nil && seat.User.GetFirstName() == user.GetFirstName() {
-			// Update the seat information for the user
-			seat.User = user
-			seat.SeatNumber = int32(seatNumber)
+	if err := b.checkVersion(modifyReq.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
 
-			// Set found flag to true
-			found = true
-			break
+	// Pick the requested seat, or the first free one in the requested section
+	d := h.departures[b.ticket.GetDepartureId()]
+	var newSeat *v1.Seat
+	if modifyReq.GetNewSeatNumber() != 0 {
+		newSeat = d.seat(modifyReq.GetNewSeatNumber())
+		if newSeat == nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("requested seat does not exist"))
+		}
+		if newSeat.GetUser() != nil && newSeat != b.seat {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("requested seat is already taken"))
+		}
+	} else {
+		newSeat = d.freeSeat(modifyReq.GetSectionType())
+		if newSeat == nil {
+			return nil, connect.NewError(connect.CodeResourceExhausted, errNoSeats)
 		}
 	}
 
-	// If user is not found, return an error
-	if !found {
-		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found in seats"))
+	// Move the user to the new seat
+	err = h.record(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_SeatModified{SeatModified: &v1.SeatModified{
+		BookingId:  b.id,
+		SeatNumber: newSeat.GetSeatNumber(),
+	}}})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return a success response
-	return connect.NewResponse(&v1.ModifySeatResponse{}), nil
+	return connect.NewResponse(&v1.ModifySeatResponse{Receipt: b.receipt()}), nil
+}
+
+// receipt builds the receipt returned to the user for a booking. It copies the
+// ticket, which may change again once h.mu is released.
+func (b *booking) receipt() *v1.Receipt {
+	return &v1.Receipt{
+		Ticket:             proto.Clone(b.ticket).(*v1.Ticket),
+		BookingId:          b.id,
+		Status:             b.status,
+		PurchasedAt:        timestamppb.New(b.purchasedAt),
+		PreviousBookingIds: b.previousIDs,
+		Version:            b.version,
+	}
+}
+
+// checkVersion returns an Aborted error when expected is set and the booking
+// has changed since that version.
+func (b *booking) checkVersion(expected int64) error {
+	if expected != 0 && expected != b.version {
+		return connect.NewError(connect.CodeAborted, fmt.Errorf("booking %s is at version %d, not %d", b.id, b.version, expected))
+	}
+	return nil
+}
+
+// release frees the seat held by a booking and moves it to status. The ticket
+// keeps a copy of the seat so receipts still show where the user sat.
+func (h *MyTrainTicketingServiceHandler) release(b *booking, status v1.BookingStatus) {
+	b.ticket.Seat = &v1.Seat{SeatNumber: b.seat.GetSeatNumber(), User: b.seat.GetUser()}
+	b.seat.User = nil
+	b.status = status
+	b.version++
+}
+
+// newBookingID returns a random identifier for a booking.
+func newBookingID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("failed to generate booking ID: %v", err))
+	}
+	return hex.EncodeToString(buf)
 }
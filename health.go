@@ -0,0 +1,169 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	bolt "go.etcd.io/bbolt"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+)
+
+// HEALTH_CHECK_TIMEOUT bounds how long a health check waits for the ledger to
+// answer before reporting it as down.
+const HEALTH_CHECK_TIMEOUT = 2 * time.Second
+
+// HEALTH_WATCH_INTERVAL is how often a Watch checks the status again.
+const HEALTH_WATCH_INTERVAL = time.Second
+
+// WAL_HEALTH_PROBE is the file a health check of the write-ahead log writes to
+// find out whether its directory still takes writes.
+const WAL_HEALTH_PROBE = "health-probe.tmp"
+
+// HealthChecker is implemented by ledgers that can tell whether the storage
+// they are kept in can be reached. While it can't, health checks report the
+// handler as NOT_SERVING.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// Mount serves h on mux, along with the grpc.health.v1 health service and
+// gRPC server reflection so load balancers can check on the handler and tools
+// such as grpcurl can find its RPCs. Neither needs a token. The REST routes of
+// h are served under REST_PATH_PREFIX, and their OpenAPI document, which
+// doesn't need a token either, at OPENAPI_PATH.
+func (h *MyTrainTicketingServiceHandler) Mount(mux *http.ServeMux) {
+	path, handler := h.Handler()
+	mux.Handle(path, handler)
+	mux.Handle(REST_PATH_PREFIX, newRESTGateway(handler))
+	mux.HandleFunc(OPENAPI_PATH, serveOpenAPI)
+	mux.Handle(newHealthHandler(&healthService{h: h}))
+
+	reflector := grpcreflect.NewStaticReflector(ticketingv1.TrainTicketingServiceName, healthv1.Health_ServiceDesc.ServiceName)
+	mux.Handle(grpcreflect.NewHandlerV1(reflector))
+	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+}
+
+// Drain makes health checks report h as NOT_SERVING, so load balancers stop
+// sending it requests before the server shuts down. Requests that still
+// arrive are served as usual.
+func (h *MyTrainTicketingServiceHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// newHealthHandler serves s as grpc.health.v1.Health, returning the path to
+// mount it on. The messages are those of gRPC's own grpc_health_v1 package,
+// so the service is registered once however many health packages a binary
+// links in.
+func newHealthHandler(s *healthService) (string, http.Handler) {
+	check := connect.NewUnaryHandler(healthv1.Health_Check_FullMethodName, s.Check)
+	watch := connect.NewServerStreamHandler(healthv1.Health_Watch_FullMethodName, s.Watch)
+	return "/" + healthv1.Health_ServiceDesc.ServiceName + "/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case healthv1.Health_Check_FullMethodName:
+			check.ServeHTTP(w, r)
+		case healthv1.Health_Watch_FullMethodName:
+			watch.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// healthService implements grpc.health.v1.Health. It knows the ticketing
+// service and the server as a whole, named by the empty string, and both share
+// one status.
+type healthService struct {
+	h *MyTrainTicketingServiceHandler
+}
+
+// Check implements the Check method of the health service.
+func (s *healthService) Check(ctx context.Context, req *connect.Request[healthv1.HealthCheckRequest]) (*connect.Response[healthv1.HealthCheckResponse], error) {
+	status := s.status(ctx, req.Msg.GetService())
+	if status == healthv1.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("unknown service "+req.Msg.GetService()))
+	}
+	return connect.NewResponse(&healthv1.HealthCheckResponse{Status: status}), nil
+}
+
+// Watch implements the Watch method of the health service. It sends the status
+// straight away and then every time it changes, until the client goes away or
+// h is drained, so watches don't hold up shutdown.
+func (s *healthService) Watch(ctx context.Context, req *connect.Request[healthv1.HealthCheckRequest], stream *connect.ServerStream[healthv1.HealthCheckResponse]) error {
+	ticker := time.NewTicker(HEALTH_WATCH_INTERVAL)
+	defer ticker.Stop()
+
+	last := healthv1.HealthCheckResponse_UNKNOWN
+	for {
+		if status := s.status(ctx, req.Msg.GetService()); status != last {
+			if err := stream.Send(&healthv1.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+			last = status
+		}
+		if s.h.draining.Load() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// status works out the status of service.
+func (s *healthService) status(ctx context.Context, service string) healthv1.HealthCheckResponse_ServingStatus {
+	if service != "" && service != ticketingv1.TrainTicketingServiceName {
+		return healthv1.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	if s.h.draining.Load() {
+		return healthv1.HealthCheckResponse_NOT_SERVING
+	}
+	if checker, ok := s.h.ledger.(HealthChecker); ok {
+		ctx, cancel := context.WithTimeout(ctx, HEALTH_CHECK_TIMEOUT)
+		defer cancel()
+		if err := checker.CheckHealth(ctx); err != nil {
+			return healthv1.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	return healthv1.HealthCheckResponse_SERVING
+}
+
+// CheckHealth implements HealthChecker by checking the ledger file is still
+// open.
+func (l *BoltLedger) CheckHealth(ctx context.Context) error {
+	return l.db.View(func(tx *bolt.Tx) error { return nil })
+}
+
+// CheckHealth implements HealthChecker by pinging the database.
+func (l *PostgresLedger) CheckHealth(ctx context.Context) error {
+	return l.pool.Ping(ctx)
+}
+
+// CheckHealth implements HealthChecker by writing and syncing a probe file
+// next to the segments, so a directory that can still be reached but no longer
+// takes writes, such as a full or failed disk, is reported as down.
+func (w *WAL) CheckHealth(ctx context.Context) error {
+	w.mu.Lock()
+	closed := w.file == nil
+	w.mu.Unlock()
+	if closed {
+		return errors.New("write-ahead log is closed")
+	}
+
+	path := filepath.Join(w.dir, WAL_HEALTH_PROBE)
+	defer os.Remove(path)
+	if err := writeFileSync(path, []byte("ok")); err != nil {
+		return fmt.Errorf("failed to write to write-ahead log directory: %w", err)
+	}
+	return nil
+}
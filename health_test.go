@@ -0,0 +1,155 @@
+package ticketing_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	connect "connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+)
+
+// healthClient calls the grpc.health.v1.Health service of a test server.
+type healthClient struct {
+	check *connect.Client[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse]
+	watch *connect.Client[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse]
+}
+
+func newHealthClient(srv *httptest.Server, opts ...connect.ClientOption) *healthClient {
+	return &healthClient{
+		check: connect.NewClient[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse](srv.Client(), srv.URL+healthv1.Health_Check_FullMethodName, opts...),
+		watch: connect.NewClient[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse](srv.Client(), srv.URL+healthv1.Health_Watch_FullMethodName, opts...),
+	}
+}
+
+func checkHealth(t *testing.T, client *healthClient, service string) healthv1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	res, err := client.check.CallUnary(context.Background(), connect.NewRequest(&healthv1.HealthCheckRequest{Service: service}))
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	return res.Msg.GetStatus()
+}
+
+func TestHealthCheck(t *testing.T) {
+	handler, srv := startServer(t)
+	// Load balancers check without a token
+	client := newHealthClient(srv, connect.WithGRPC())
+
+	for _, service := range []string{"", ticketingv1.TrainTicketingServiceName} {
+		if status := checkHealth(t, client, service); status != healthv1.HealthCheckResponse_SERVING {
+			t.Fatalf("expected %q to be serving, got %v", service, status)
+		}
+	}
+	_, err := client.check.CallUnary(context.Background(), connect.NewRequest(&healthv1.HealthCheckRequest{Service: "nothing.v1.Nothing"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound for an unknown service, got %v", err)
+	}
+
+	// A watch sees the status flip once the handler is drained, and then ends
+	stream, err := client.watch.CallServerStream(context.Background(), connect.NewRequest(&healthv1.HealthCheckRequest{Service: ticketingv1.TrainTicketingServiceName}))
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if !stream.Receive() || stream.Msg().GetStatus() != healthv1.HealthCheckResponse_SERVING {
+		t.Fatalf("expected the watch to start with SERVING, got %v (%v)", stream.Msg(), stream.Err())
+	}
+	handler.Drain()
+	if !stream.Receive() || stream.Msg().GetStatus() != healthv1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected the watch to see NOT_SERVING, got %v (%v)", stream.Msg(), stream.Err())
+	}
+	if stream.Receive() {
+		t.Fatalf("expected the watch to end, got %v", stream.Msg())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("expected the watch to end cleanly, got %v", err)
+	}
+	if status := checkHealth(t, client, ""); status != healthv1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected a drained handler not to be serving, got %v", status)
+	}
+
+	// The RPCs are still served while draining
+	withToken := connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("Authorization", "Bearer "+server.DEMO_AUTH_TOKEN)
+			return next(ctx, req)
+		}
+	})
+	rpcClient := ticketingv1.NewTrainTicketingServiceClient(srv.Client(), srv.URL, connect.WithInterceptors(withToken))
+	purchaseTicket(t, rpcClient, "Jane", "Roe", "jane@example.com")
+}
+
+func TestHealthCheckStorageOutage(t *testing.T) {
+	ledger, err := server.OpenBoltLedger(filepath.Join(t.TempDir(), "ledger.db"))
+	if err != nil {
+		t.Fatalf("OpenBoltLedger failed: %v", err)
+	}
+	_, srv := startServer(t, server.WithLedger(ledger))
+	client := newHealthClient(srv)
+
+	if status := checkHealth(t, client, ""); status != healthv1.HealthCheckResponse_SERVING {
+		t.Fatalf("expected the handler to be serving, got %v", status)
+	}
+	ledger.Close()
+	if status := checkHealth(t, client, ticketingv1.TrainTicketingServiceName); status != healthv1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected the handler not to be serving without its ledger, got %v", status)
+	}
+}
+
+func TestHealthCheckWALOutage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "wal")
+	wal, err := server.OpenWAL(dir)
+	if err != nil {
+		t.Fatalf("OpenWAL failed: %v", err)
+	}
+	defer wal.Close()
+	_, srv := startServer(t, server.WithLedger(wal))
+	client := newHealthClient(srv)
+
+	if status := checkHealth(t, client, ""); status != healthv1.HealthCheckResponse_SERVING {
+		t.Fatalf("expected the handler to be serving, got %v", status)
+	}
+	if _, err := os.Stat(filepath.Join(dir, server.WAL_HEALTH_PROBE)); !os.IsNotExist(err) {
+		t.Fatalf("expected the health probe to be removed, got %v", err)
+	}
+
+	// The log's directory can still be looked up, but nothing can be written
+	// to it any more
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("RemoveAll failed: %v", err)
+	}
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if status := checkHealth(t, client, ""); status != healthv1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected the handler not to be serving while its log can't be written, got %v", status)
+	}
+}
+
+func TestReflection(t *testing.T) {
+	_, srv := startServer(t)
+	stream := grpcreflect.NewClient(srv.Client(), srv.URL, connect.WithGRPC()).NewStream(context.Background())
+	defer stream.Close()
+
+	services, err := stream.ListServices()
+	if err != nil {
+		t.Fatalf("ListServices failed: %v", err)
+	}
+	if fmt.Sprint(services) != fmt.Sprintf("[%s %s]", ticketingv1.TrainTicketingServiceName, healthv1.Health_ServiceDesc.ServiceName) {
+		t.Fatalf("expected the health and ticketing services, got %v", services)
+	}
+	files, err := stream.FileContainingSymbol(ticketingv1.TrainTicketingServiceName)
+	if err != nil {
+		t.Fatalf("FileContainingSymbol failed: %v", err)
+	}
+	if len(files) == 0 || files[0].GetName() != "proto/train_ticketing/v1/ticketing.proto" {
+		t.Fatalf("expected ticketing.proto first, got %d files", len(files))
+	}
+}
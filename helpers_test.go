@@ -0,0 +1,178 @@
+package ticketing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// startServer creates a handler configured by opts and serves it, mounted
+// with its health, reflection and REST routes, over HTTP/2 until the test
+// ends. Every test serves its handlers this way.
+func startServer(tb testing.TB, opts ...server.Option) (*server.MyTrainTicketingServiceHandler, *httptest.Server) {
+	tb.Helper()
+	handler, err := server.New(opts...)
+	if err != nil {
+		tb.Fatalf("New failed: %v", err)
+	}
+	mux := http.NewServeMux()
+	handler.Mount(mux)
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	tb.Cleanup(srv.Close)
+	return handler, srv
+}
+
+// newClient returns a client of the handler served by srv that sends token
+// as its bearer token, unless token is empty.
+func newClient(srv *httptest.Server, token string, opts ...connect.ClientOption) ticketingv1.TrainTicketingServiceClient {
+	httpClient := srv.Client()
+	if token != "" {
+		httpClient = &http.Client{Transport: bearerTransport{token: token, next: httpClient.Transport}}
+	}
+	return ticketingv1.NewTrainTicketingServiceClient(httpClient, srv.URL, opts...)
+}
+
+// newTestClient serves a handler configured by opts and returns a client of
+// it authenticated with the demo token.
+func newTestClient(tb testing.TB, opts ...server.Option) ticketingv1.TrainTicketingServiceClient {
+	tb.Helper()
+	_, srv := startServer(tb, opts...)
+	return newClient(srv, server.DEMO_AUTH_TOKEN)
+}
+
+// withHeader sets a header on every request a client sends.
+func withHeader(name, value string) connect.ClientOption {
+	return connect.WithInterceptors(connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set(name, value)
+			return next(ctx, req)
+		}
+	}))
+}
+
+// withOptions applies several options as one.
+func withOptions(opts ...server.Option) server.Option {
+	return func(h *server.MyTrainTicketingServiceHandler) {
+		for _, opt := range opts {
+			opt(h)
+		}
+	}
+}
+
+// bearerTransport adds a bearer token to every request.
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
+
+// testDepartures replaces the default departure with a morning train from
+// London to Paris for 20, an evening one ten hours later for 30, and a train
+// to Lille for 15 in between. Times are kept to the second, as ledgers store
+// them.
+func testDepartures(morning time.Time) server.Option {
+	morning = morning.Truncate(time.Second)
+	return server.WithDepartures(
+		&v1.Departure{Id: "morning", From: "London", To: "Paris", DepartureTime: timestamppb.New(morning), Fare: 20},
+		&v1.Departure{Id: "evening", From: "London", To: "Paris", DepartureTime: timestamppb.New(morning.Add(10 * time.Hour)), Fare: 30},
+		&v1.Departure{Id: "lille", From: "London", To: "Lille", DepartureTime: timestamppb.New(morning.Add(2 * time.Hour)), Fare: 15},
+	)
+}
+
+// purchaseOption changes the request sent by purchase.
+type purchaseOption func(req *connect.Request[v1.PurchaseTicketRequest])
+
+// passenger books the ticket for someone other than Jane Roe.
+func passenger(firstName, lastName string) purchaseOption {
+	return func(req *connect.Request[v1.PurchaseTicketRequest]) {
+		user := req.Msg.GetTicket().GetUser()
+		user.FirstName, user.LastName = firstName, lastName
+	}
+}
+
+func onDeparture(departureID string) purchaseOption {
+	return func(req *connect.Request[v1.PurchaseTicketRequest]) {
+		req.Msg.Ticket.DepartureId = departureID
+	}
+}
+
+func withDiscount(code string) purchaseOption {
+	return func(req *connect.Request[v1.PurchaseTicketRequest]) {
+		req.Msg.Ticket.DiscountCode = code
+	}
+}
+
+func paidWith(token string) purchaseOption {
+	return func(req *connect.Request[v1.PurchaseTicketRequest]) {
+		req.Msg.PaymentMethod = &v1.PaymentMethod{Token: token}
+	}
+}
+
+func withIdempotencyKey(key string) purchaseOption {
+	return func(req *connect.Request[v1.PurchaseTicketRequest]) {
+		req.Header().Set(server.IDEMPOTENCY_KEY_HEADER, key)
+	}
+}
+
+// purchase buys a ticket for Jane Roe, reached at email, on the default
+// departure, as changed by opts.
+func purchase(client ticketingv1.TrainTicketingServiceClient, email string, opts ...purchaseOption) (*v1.Receipt, error) {
+	req := connect.NewRequest(&v1.PurchaseTicketRequest{
+		Ticket: &v1.Ticket{User: &v1.User{FirstName: "Jane", LastName: "Roe", Email: email}},
+	})
+	for _, opt := range opts {
+		opt(req)
+	}
+	res, err := client.PurchaseTicket(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return res.Msg.GetReceipt(), nil
+}
+
+// purchaseTicket buys a ticket for the given user, as changed by opts, and
+// returns its booking ID.
+func purchaseTicket(t *testing.T, client ticketingv1.TrainTicketingServiceClient, firstName, lastName, email string, opts ...purchaseOption) string {
+	t.Helper()
+	receipt, err := purchase(client, email, append([]purchaseOption{passenger(firstName, lastName)}, opts...)...)
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	return receipt.GetBookingId()
+}
+
+// testClock is a clock tests move forward by hand.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
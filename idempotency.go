@@ -0,0 +1,211 @@
+package ticketing
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	connect "connectrpc.com/connect"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+	"google.golang.org/protobuf/proto"
+)
+
+// IDEMPOTENCY_KEY_HEADER carries the client-chosen key that makes a mutating
+// RPC safe to retry.
+const IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+
+// DEFAULT_IDEMPOTENCY_RETENTION is how long a response is kept for replay.
+const DEFAULT_IDEMPOTENCY_RETENTION = 24 * time.Hour
+
+// mutatingProcedures are the RPCs that honour IDEMPOTENCY_KEY_HEADER.
+var mutatingProcedures = map[string]bool{
+	ticketingv1.TrainTicketingServicePurchaseTicketProcedure: true,
+	ticketingv1.TrainTicketingServiceRemoveUserProcedure:     true,
+	ticketingv1.TrainTicketingServiceModifySeatProcedure:     true,
+	ticketingv1.TrainTicketingServiceCancelBookingProcedure:  true,
+	ticketingv1.TrainTicketingServiceExchangeTicketProcedure: true,
+	ticketingv1.TrainTicketingServiceReviewBookingProcedure:  true,
+	ticketingv1.TrainTicketingServiceImportBookingsProcedure: true,
+}
+
+// WithIdempotencyRetention overrides DEFAULT_IDEMPOTENCY_RETENTION.
+func WithIdempotencyRetention(retention time.Duration) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.idempotency.retention = retention
+	}
+}
+
+// idempotencyStore remembers the outcome of mutating RPCs by idempotency key.
+// Calls that fail before changing anything aren't stored, so retrying them
+// with the same key simply tries again. Calls that fail after their ledger
+// event was recorded, such as a cancellation whose refund didn't go through,
+// have their error stored and replayed like a response, as running them again
+// would fail differently or change something twice.
+type idempotencyStore struct {
+	mu        sync.Mutex
+	retention time.Duration
+	now       func() time.Time
+	entries   map[idempotencyKey]*idempotencyEntry
+	// Finished entries, oldest first. Every entry is kept for the same time,
+	// so they also expire in this order.
+	finished *list.List
+}
+
+// idempotencyKey scopes a client's idempotency key to the RPC and the caller,
+// so one caller can't replay another's response by reusing their key.
+type idempotencyKey struct {
+	procedure string
+	subject   string // The subject of the caller's Identity, if any
+	key       string
+}
+
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte
+	response    connect.AnyResponse // nil while the first call is in flight, or if it failed
+	err         *connect.Error      // Set if the first call failed after its ledger event was recorded
+	expires     time.Time
+}
+
+// recordedError marks an error returned after the call's ledger event was
+// recorded, so the change stands even though the call failed.
+type recordedError struct {
+	error
+}
+
+func (e recordedError) Unwrap() error {
+	return e.error
+}
+
+// afterRecording wraps err, returned once the call's ledger event has been
+// recorded, so the idempotency store replays it rather than letting a retry
+// run the call again.
+func afterRecording(code connect.Code, err error) error {
+	return connect.NewError(code, recordedError{err})
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{
+		retention: DEFAULT_IDEMPOTENCY_RETENTION,
+		entries:   make(map[idempotencyKey]*idempotencyEntry),
+		finished:  list.New(),
+	}
+}
+
+// Interceptor replays the stored response when a mutating RPC is retried with
+// an idempotency key it has already seen, and rejects reuse of a key with a
+// different request. ImportBookings streams its request, so it isn't seen
+// here; it calls do itself once it has read every row.
+func (s *idempotencyStore) Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			key, ok := s.key(ctx, req.Spec().Procedure, req.Header())
+			if !ok {
+				return next(ctx, req)
+			}
+			msg, ok := req.Any().(proto.Message)
+			if !ok {
+				return nil, connect.NewError(connect.CodeInternal, errors.New("request is not a protobuf message"))
+			}
+			return s.do(key, msg, func() (connect.AnyResponse, error) {
+				return next(ctx, req)
+			})
+		}
+	}
+}
+
+// key returns the idempotency key of a call to procedure, and whether it has
+// one that should be honoured.
+func (s *idempotencyStore) key(ctx context.Context, procedure string, header http.Header) (idempotencyKey, bool) {
+	key := idempotencyKey{procedure: procedure, key: header.Get(IDEMPOTENCY_KEY_HEADER)}
+	if key.key == "" || !mutatingProcedures[procedure] {
+		return key, false
+	}
+	if identity := IdentityFrom(ctx); identity != nil {
+		key.subject = identity.Subject
+	}
+	return key, true
+}
+
+// do makes call unless a call with key and the same request has already been
+// made, in which case its outcome is replayed.
+func (s *idempotencyStore) do(key idempotencyKey, req proto.Message, call func() (connect.AnyResponse, error)) (connect.AnyResponse, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	replay, err := s.begin(key, sha256.Sum256(data))
+	if replay != nil || err != nil {
+		return replay, err
+	}
+
+	resp, err := call()
+	s.finish(key, resp, err)
+	return resp, err
+}
+
+// begin claims key for a new call, or returns the outcome to replay.
+func (s *idempotencyStore) begin(key idempotencyKey, fingerprint [sha256.Size]byte) (connect.AnyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for front := s.finished.Front(); front != nil; front = s.finished.Front() {
+		expired := front.Value.(idempotencyKey)
+		if now.Before(s.entries[expired].expires) {
+			break
+		}
+		s.finished.Remove(front)
+		delete(s.entries, expired)
+	}
+
+	entry, ok := s.entries[key]
+	if !ok {
+		s.entries[key] = &idempotencyEntry{fingerprint: fingerprint}
+		return nil, nil
+	}
+	if !bytes.Equal(entry.fingerprint[:], fingerprint[:]) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("idempotency key was already used with a different request"))
+	}
+	switch {
+	case entry.err != nil:
+		return nil, connect.NewError(entry.err.Code(), errors.New(entry.err.Message()))
+	case entry.response == nil:
+		return nil, connect.NewError(connect.CodeAborted, errors.New("a request with this idempotency key is still in progress"))
+	}
+	return cloneResponse(entry.response), nil
+}
+
+// finish stores the outcome of a call started with begin.
+func (s *idempotencyStore) finish(key idempotencyKey, resp connect.AnyResponse, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.entries[key]
+	var connectErr *connect.Error
+	switch {
+	case err == nil:
+		entry.response = cloneResponse(resp)
+	case errors.As(err, new(recordedError)) && errors.As(err, &connectErr):
+		entry.err = connectErr
+	default:
+		delete(s.entries, key)
+		return
+	}
+	entry.expires = s.now().Add(s.retention)
+	s.finished.PushBack(key)
+}
+
+// cloneResponse deep copies a *connect.Response[T], so that a stored response
+// isn't affected by later changes to the bookings it was built from.
+func cloneResponse(resp connect.AnyResponse) connect.AnyResponse {
+	clone := reflect.New(reflect.TypeOf(resp).Elem())
+	msg := proto.Clone(resp.Any().(proto.Message))
+	clone.Elem().FieldByName("Msg").Set(reflect.ValueOf(msg))
+	return clone.Interface().(connect.AnyResponse)
+}
@@ -0,0 +1,167 @@
+package ticketing_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+func TestIdempotentPurchaseTicket(t *testing.T) {
+	now := time.Now()
+	provider := &server.FakePaymentProvider{}
+	client := newTestClient(t,
+		server.WithPaymentProvider(provider),
+		server.WithIdempotencyRetention(time.Hour),
+		server.WithClock(func() time.Time { return now }),
+	)
+
+	first, err := purchase(client, "jane@example.com", withIdempotencyKey("retry-1"))
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+
+	// A retry replays the original receipt without a second seat or charge
+	retry, err := purchase(client, "jane@example.com", withIdempotencyKey("retry-1"))
+	if err != nil {
+		t.Fatalf("retried PurchaseTicket failed: %v", err)
+	}
+	if retry.GetBookingId() != first.GetBookingId() {
+		t.Fatalf("expected replayed booking %s, got %s", first.GetBookingId(), retry.GetBookingId())
+	}
+	if len(provider.Payments()) != 1 {
+		t.Fatalf("expected a single charge, got %+v", provider.Payments())
+	}
+
+	// Reusing the key for a different purchase is rejected
+	_, err = purchase(client, "someone-else@example.com", withIdempotencyKey("retry-1"))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for reused key, got %v", err)
+	}
+
+	// Once the retention window has passed the key can be used again
+	now = now.Add(2 * time.Hour)
+	again, err := purchase(client, "jane@example.com", withIdempotencyKey("retry-1"))
+	if err != nil {
+		t.Fatalf("PurchaseTicket after retention failed: %v", err)
+	}
+	if again.GetBookingId() == first.GetBookingId() {
+		t.Fatalf("expected a new booking once the key expired")
+	}
+}
+
+func TestIdempotentCancelBooking(t *testing.T) {
+	client := newTestClient(t)
+
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+	cancel := func() (*connect.Response[v1.CancelBookingResponse], error) {
+		req := connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID})
+		req.Header().Set(server.IDEMPOTENCY_KEY_HEADER, "cancel-1")
+		return client.CancelBooking(context.Background(), req)
+	}
+	first, err := cancel()
+	if err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+
+	// Without the key a second cancellation fails, with it the first result is replayed
+	retry, err := cancel()
+	if err != nil {
+		t.Fatalf("retried CancelBooking failed: %v", err)
+	}
+	if retry.Msg.GetCancellationReceipt().GetRefundAmount() != first.Msg.GetCancellationReceipt().GetRefundAmount() {
+		t.Fatalf("expected replayed refund %v, got %v", first.Msg.GetCancellationReceipt().GetRefundAmount(), retry.Msg.GetCancellationReceipt().GetRefundAmount())
+	}
+}
+
+// refundsDown is a payment provider whose refunds fail, as one that is down
+// would.
+type refundsDown struct {
+	*server.FakePaymentProvider
+}
+
+func (p refundsDown) Refund(ctx context.Context, authorizationID string, amount float32) error {
+	return errors.New("connection refused")
+}
+
+func TestIdempotentCancelBookingReplaysRefundFailure(t *testing.T) {
+	client := newTestClient(t, server.WithPaymentProvider(refundsDown{&server.FakePaymentProvider{}}))
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+
+	cancel := func() error {
+		req := connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID})
+		req.Header().Set(server.IDEMPOTENCY_KEY_HEADER, "cancel-1")
+		_, err := client.CancelBooking(context.Background(), req)
+		return err
+	}
+	first := cancel()
+	if connect.CodeOf(first) != connect.CodeUnavailable || !strings.Contains(first.Error(), "booking was cancelled") {
+		t.Fatalf("expected Unavailable saying the booking was cancelled, got %v", first)
+	}
+
+	// The booking is cancelled, so a retry is told what happened rather than
+	// that only confirmed bookings can be cancelled
+	if retry := cancel(); connect.CodeOf(retry) != connect.CodeUnavailable || retry.Error() != first.Error() {
+		t.Fatalf("expected the first error %v to be replayed, got %v", first, retry)
+	}
+}
+
+func TestIdempotentImportBookings(t *testing.T) {
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)))
+
+	importWithKey := func(first string) (*connect.Response[v1.ImportBookingsResponse], error) {
+		stream := client.ImportBookings(context.Background())
+		stream.RequestHeader().Set(server.IDEMPOTENCY_KEY_HEADER, "import-1")
+		stream.Send(&v1.ImportBookingsRequest{Rows: []*v1.ImportBookingRow{importRow(first, "morning", v1.Section_SECTION_TYPE_UNSPECIFIED, 0)}})
+		return stream.CloseAndReceive()
+	}
+	first, err := importWithKey("Ann")
+	if err != nil {
+		t.Fatalf("ImportBookings failed: %v", err)
+	}
+
+	// A retry replays the first import without booking a second seat
+	retry, err := importWithKey("Ann")
+	if err != nil {
+		t.Fatalf("retried ImportBookings failed: %v", err)
+	}
+	if want := first.Msg.GetReceipts()[0].GetBookingId(); retry.Msg.GetReceipts()[0].GetBookingId() != want {
+		t.Fatalf("expected replayed booking %s, got %v", want, retry.Msg.GetReceipts())
+	}
+	if seats := takenSeats(t, client, "morning"); len(seats) != 1 {
+		t.Fatalf("expected a single seat to be taken, got %v", seats)
+	}
+
+	// Reusing the key for other rows is rejected
+	if _, err := importWithKey("Bob"); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for reused key, got %v", err)
+	}
+}
+
+func TestIdempotencyKeysBelongToTheirCaller(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)))
+	alice := newClient(srv, signJWT(t, "alice"))
+	bob := newClient(srv, signJWT(t, "bob"))
+
+	first, err := purchase(alice, "jane@example.com", withIdempotencyKey("retry-1"))
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	// Another caller reusing the key makes a purchase of their own rather than
+	// seeing alice's receipt
+	other, err := purchase(bob, "jane@example.com", withIdempotencyKey("retry-1"))
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	if other.GetBookingId() == first.GetBookingId() {
+		t.Fatal("expected bob's purchase not to replay alice's receipt")
+	}
+}
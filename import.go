@@ -0,0 +1,286 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+)
+
+// MAX_IMPORT_ROWS caps the number of rows in one ImportBookings stream.
+const MAX_IMPORT_ROWS = 10000
+
+// importRow is a row of an import together with the departure, seat and price
+// it was given.
+type importRow struct {
+	number    int32 // Rows are numbered from 1
+	row       *v1.ImportBookingRow
+	bookingID string // Seats are claimed for it from a SeatAllocator
+	departure *departure
+	seat      *v1.Seat
+	price     float32
+}
+
+// ImportBookings implements the ImportBookings method of TrainTicketingServiceHandler.
+// Every row is read and checked before anything is booked, and the bookings
+// are recorded in a single ledger event, so either all of them are made or
+// none is. Imported bookings were paid for outside the service, so no payment
+// is taken. Only admins may import bookings.
+func (h *MyTrainTicketingServiceHandler) ImportBookings(ctx context.Context, stream *connect.ClientStream[v1.ImportBookingsRequest]) (*connect.Response[v1.ImportBookingsResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Read the whole import first
+	var rows []*importRow
+	dryRun := false
+	for stream.Receive() {
+		dryRun = dryRun || stream.Msg().GetDryRun()
+		for _, row := range stream.Msg().GetRows() {
+			rows = append(rows, &importRow{number: int32(len(rows) + 1), row: row, bookingID: newBookingID()})
+		}
+		if len(rows) > MAX_IMPORT_ROWS {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at most %d rows can be imported at once", MAX_IMPORT_ROWS))
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("no rows to import"))
+	}
+
+	// Replay an import retried with the same idempotency key, now that the
+	// whole request is known
+	key, ok := h.idempotency.key(ctx, ticketingv1.TrainTicketingServiceImportBookingsProcedure, stream.RequestHeader())
+	if !ok {
+		return h.importRows(ctx, rows, dryRun)
+	}
+	req := &v1.ImportBookingsRequest{DryRun: dryRun}
+	for _, r := range rows {
+		req.Rows = append(req.Rows, r.row)
+	}
+	res, err := h.idempotency.do(key, req, func() (connect.AnyResponse, error) {
+		res, err := h.importRows(ctx, rows, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*connect.Response[v1.ImportBookingsResponse]), nil
+}
+
+// importRows seats and books rows, or only seats them for a dry run.
+func (h *MyTrainTicketingServiceHandler) importRows(ctx context.Context, rows []*importRow, dryRun bool) (*connect.Response[v1.ImportBookingsResponse], error) {
+	// Check everything that doesn't depend on which seats are free
+	var invalid []*v1.ImportRowError
+	var departureIDs []string
+	for _, r := range rows {
+		if err := h.checkImportRow(r); err != nil {
+			invalid = append(invalid, &v1.ImportRowError{Row: r.number, Message: err.Error()})
+			continue
+		}
+		departureIDs = append(departureIDs, r.departure.info.GetId())
+	}
+
+	// Lock the departures being booked, so the seats given out can't be
+	// taken before they are recorded, and then the maps for reading
+	defer h.lockDepartures(departureIDs...)()
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	defer h.mu.RUnlock()
+
+	for attempt := 1; ; attempt++ {
+		// Give every valid row a seat, and only go ahead if all rows got one
+		seatErrs, err := h.seatImportRows(ctx, rows, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		errs := append(append([]*v1.ImportRowError(nil), invalid...), seatErrs...)
+		response := &v1.ImportBookingsResponse{DryRun: dryRun, Rows: int32(len(rows)), Errors: sortImportErrors(errs)}
+		if len(errs) > 0 {
+			return connect.NewResponse(response), nil
+		}
+		tickets := make([]*v1.Ticket, len(rows))
+		for i, r := range rows {
+			tickets[i] = r.ticket()
+			response.TotalPrice += r.price
+		}
+		if dryRun {
+			for _, ticket := range tickets {
+				response.Receipts = append(response.Receipts, &v1.Receipt{Ticket: ticket})
+			}
+			return connect.NewResponse(response), nil
+		}
+
+		// Record every booking at once. If another handler sharing the ledger
+		// got in first, seat the rows again; seats claimed for them are kept
+		imported := &v1.BookingsImported{}
+		for i, ticket := range tickets {
+			imported.Bookings = append(imported.Bookings, &v1.ImportedBooking{BookingId: rows[i].bookingID, Ticket: ticket})
+		}
+		err = h.record(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_BookingsImported{BookingsImported: imported}})
+		if errors.Is(err, ErrLedgerConflict) && attempt < LEDGER_CONFLICT_RETRIES {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range imported.GetBookings() {
+			response.Receipts = append(response.Receipts, h.bookings[b.GetBookingId()].receipt())
+		}
+		return connect.NewResponse(response), nil
+	}
+}
+
+// checkImportRow looks up the departure of a row and checks the passenger,
+// the requested seat and the price. The row's departure is only set when
+// everything checks out.
+func (h *MyTrainTicketingServiceHandler) checkImportRow(r *importRow) error {
+	user := r.row.GetUser()
+	if user.GetFirstName() == "" || user.GetLastName() == "" || user.GetEmail() == "" {
+		return errors.New("first name, last name and email are required")
+	}
+
+	id := r.row.GetDepartureId()
+	if id == "" {
+		id = h.defaultDepartureID
+	}
+	d, ok := h.departures[id]
+	if !ok {
+		return fmt.Errorf("departure %q not found", id)
+	}
+	if !h.owns(id) {
+		return fmt.Errorf("departure %q is served by another cluster member", id)
+	}
+	if d.departed(h.now()) {
+		return fmt.Errorf("departure %q has already left", id)
+	}
+
+	section := r.row.GetSectionType()
+	if number := r.row.GetSeatNumber(); number != 0 {
+		if d.seat(number) == nil {
+			return fmt.Errorf("seat %d does not exist", number)
+		}
+		if section != v1.Section_SECTION_TYPE_UNSPECIFIED && sectionOf(number) != section {
+			return fmt.Errorf("seat %d is not in section %s", number, sectionName(section))
+		}
+	}
+
+	price := d.info.GetFare()
+	if r.row.PriceOverride != nil {
+		price = r.row.GetPriceOverride()
+		if price < 0 {
+			return errors.New("price override must not be negative")
+		}
+	}
+	r.departure = d
+	r.price = price
+	return nil
+}
+
+// seatImportRows gives each checked row a free seat and returns the errors of
+// the rows that couldn't be seated. Rows asking for a seat are seated first,
+// so rows that only ask for a section don't take seats asked for further down.
+// The caller must hold h.mu for reading.
+func (h *MyTrainTicketingServiceHandler) seatImportRows(ctx context.Context, rows []*importRow, dryRun bool) ([]*v1.ImportRowError, error) {
+	var errs []*v1.ImportRowError
+	taken := make(map[*v1.Seat]int32) // Row numbers by the seat they were given
+	for _, r := range rows {
+		r.seat = nil
+		if r.departure == nil || r.row.GetSeatNumber() == 0 {
+			continue
+		}
+		seat := r.departure.seat(r.row.GetSeatNumber())
+		switch {
+		case seat.GetUser() != nil:
+			errs = append(errs, &v1.ImportRowError{Row: r.number, Message: fmt.Sprintf("seat %d is already taken", seat.GetSeatNumber())})
+		case taken[seat] != 0:
+			errs = append(errs, &v1.ImportRowError{Row: r.number, Message: fmt.Sprintf("seat %d is also given to row %d", seat.GetSeatNumber(), taken[seat])})
+		default:
+			r.seat = seat
+			taken[seat] = r.number
+		}
+	}
+
+	for _, r := range rows {
+		if r.departure == nil || r.row.GetSeatNumber() != 0 {
+			continue
+		}
+		section := r.row.GetSectionType()
+		seat, err := h.freeImportSeat(ctx, r, taken, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case seat != nil && taken[seat] != 0:
+			errs = append(errs, &v1.ImportRowError{Row: r.number, Message: fmt.Sprintf("seat %d is also given to row %d", seat.GetSeatNumber(), taken[seat])})
+		case seat != nil:
+			r.seat = seat
+			taken[seat] = r.number
+		case section == v1.Section_SECTION_TYPE_UNSPECIFIED:
+			errs = append(errs, &v1.ImportRowError{Row: r.number, Message: "no free seats left"})
+		default:
+			errs = append(errs, &v1.ImportRowError{Row: r.number, Message: fmt.Sprintf("no free seats left in section %s", sectionName(section))})
+		}
+	}
+	return errs, nil
+}
+
+// freeImportSeat picks a free seat for a row that only asks for a section,
+// leaving out the seats other rows were given. When the ledger is a
+// SeatAllocator the seat is claimed from it, as for a purchase, so handlers
+// sharing the ledger don't give it out too. A dry run books nothing, so it
+// only looks at the seats the handler knows are free. It returns nil when the
+// section is full.
+func (h *MyTrainTicketingServiceHandler) freeImportSeat(ctx context.Context, r *importRow, taken map[*v1.Seat]int32, dryRun bool) (*v1.Seat, error) {
+	section := r.row.GetSectionType()
+	inSection := func(seat *v1.Seat) bool {
+		return section == v1.Section_SECTION_TYPE_UNSPECIFIED || sectionOf(seat.GetSeatNumber()) == section
+	}
+	if _, ok := h.ledger.(SeatAllocator); !ok || dryRun {
+		for _, seat := range r.departure.seats {
+			if seat.GetUser() == nil && taken[seat] == 0 && inSection(seat) {
+				return seat, nil
+			}
+		}
+		return nil, nil
+	}
+
+	// The allocator falls back to other sections when the one asked for is
+	// full, and doesn't know which seats other rows asked for, so the seat
+	// it gives may still not do
+	seat, err := h.allocateSeat(ctx, r.departure, section, r.bookingID)
+	if err != nil || seat == nil || !inSection(seat) {
+		return nil, err
+	}
+	return seat, nil
+}
+
+// ticket returns the ticket of a seated row.
+func (r *importRow) ticket() *v1.Ticket {
+	info := r.departure.info
+	return &v1.Ticket{
+		From:          info.GetFrom(),
+		To:            info.GetTo(),
+		User:          r.row.GetUser(),
+		PricePaid:     r.price,
+		Seat:          &v1.Seat{SeatNumber: r.seat.GetSeatNumber()},
+		DepartureTime: info.GetDepartureTime(),
+		DepartureId:   info.GetId(),
+	}
+}
+
+// sortImportErrors puts errors in row order.
+func sortImportErrors(errs []*v1.ImportRowError) []*v1.ImportRowError {
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].GetRow() < errs[j].GetRow() })
+	return errs
+}
@@ -0,0 +1,181 @@
+package ticketing_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// importBookings streams each batch of rows in its own message.
+func importBookings(t *testing.T, client ticketingv1.TrainTicketingServiceClient, dryRun bool, batches ...[]*v1.ImportBookingRow) *v1.ImportBookingsResponse {
+	t.Helper()
+	stream := client.ImportBookings(context.Background())
+	for _, rows := range batches {
+		if err := stream.Send(&v1.ImportBookingsRequest{Rows: rows, DryRun: dryRun}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	res, err := stream.CloseAndReceive()
+	if err != nil {
+		t.Fatalf("ImportBookings failed: %v", err)
+	}
+	return res.Msg
+}
+
+func importRow(first, departureID string, section v1.Section_SectionType, seatNumber int32) *v1.ImportBookingRow {
+	return &v1.ImportBookingRow{
+		User:        &v1.User{FirstName: first, LastName: "Group", Email: first + "@example.com"},
+		DepartureId: departureID,
+		SectionType: section,
+		SeatNumber:  seatNumber,
+	}
+}
+
+// importedSeats returns the seat numbers on the receipts of an import.
+func importedSeats(res *v1.ImportBookingsResponse) []int32 {
+	var seats []int32
+	for _, receipt := range res.GetReceipts() {
+		seats = append(seats, receipt.GetTicket().GetSeat().GetSeatNumber())
+	}
+	return seats
+}
+
+func TestImportBookings(t *testing.T) {
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)))
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com", onDeparture("morning"))
+
+	// Section B rows don't take seats asked for further down, and prices can
+	// be overridden
+	discounted := importRow("Cat", "evening", v1.Section_SECTION_TYPE_UNSPECIFIED, 0)
+	price := float32(5)
+	discounted.PriceOverride = &price
+	rows := []*v1.ImportBookingRow{
+		importRow("Ann", "morning", v1.Section_SECTION_TYPE_B, 0),
+		importRow("Bob", "morning", v1.Section_SECTION_TYPE_B, 11),
+		discounted,
+	}
+	planned := importBookings(t, client, true, rows[:2], rows[2:])
+	if !planned.GetDryRun() || planned.GetRows() != 3 || len(planned.GetErrors()) != 0 || planned.GetTotalPrice() != 45 {
+		t.Fatalf("unexpected dry run summary %v", planned)
+	}
+	if seats := importedSeats(planned); len(seats) != 3 || seats[0] != 12 || seats[1] != 11 || seats[2] != 1 {
+		t.Fatalf("expected seats 12, 11 and 1, got %v", seats)
+	}
+	if planned.GetReceipts()[0].GetBookingId() != "" {
+		t.Fatal("expected a dry run not to make bookings")
+	}
+	assertEmails(t, []string{"jane@example.com"}, adminEmails(t, client, &v1.ViewAdminDetailsRequest{}))
+
+	// The bookings match the dry run
+	imported := importBookings(t, client, false, rows)
+	if imported.GetDryRun() || len(imported.GetReceipts()) != 3 || imported.GetTotalPrice() != 45 {
+		t.Fatalf("unexpected import summary %v", imported)
+	}
+	for i, receipt := range imported.GetReceipts() {
+		if receipt.GetBookingId() == "" || receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+			t.Fatalf("expected row %d to be booked, got %v", i+1, receipt)
+		}
+		if seat := receipt.GetTicket().GetSeat().GetSeatNumber(); seat != importedSeats(planned)[i] {
+			t.Fatalf("expected row %d in seat %d, got %d", i+1, importedSeats(planned)[i], seat)
+		}
+	}
+	assertEmails(t, []string{"jane@example.com", "Bob@example.com", "Ann@example.com", "Cat@example.com"},
+		adminEmails(t, client, &v1.ViewAdminDetailsRequest{}))
+
+	// Imported bookings can be cancelled like any other
+	cancelled, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: imported.GetReceipts()[2].GetBookingId()}))
+	if err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if cancelled.Msg.GetCancellationReceipt().GetRefundAmount() != 5 {
+		t.Fatalf("expected the overridden price to be refunded, got %v", cancelled.Msg.GetCancellationReceipt())
+	}
+}
+
+func TestImportBookingsReportsEveryBadRow(t *testing.T) {
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)))
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com", onDeparture("morning"))
+
+	noEmail := importRow("Ann", "morning", v1.Section_SECTION_TYPE_UNSPECIFIED, 0)
+	noEmail.User.Email = ""
+	rows := []*v1.ImportBookingRow{
+		importRow("Bob", "morning", v1.Section_SECTION_TYPE_UNSPECIFIED, 1),
+		noEmail,
+		importRow("Cat", "nowhere", v1.Section_SECTION_TYPE_UNSPECIFIED, 0),
+		importRow("Dan", "morning", v1.Section_SECTION_TYPE_B, 3),
+		importRow("Eve", "evening", v1.Section_SECTION_TYPE_UNSPECIFIED, 4),
+		importRow("Fay", "evening", v1.Section_SECTION_TYPE_UNSPECIFIED, 4),
+		importRow("Gus", "evening", v1.Section_SECTION_TYPE_UNSPECIFIED, 0),
+	}
+	for i := 0; i < 10; i++ {
+		rows = append(rows, importRow("Extra", "morning", v1.Section_SECTION_TYPE_A, 0))
+	}
+
+	res := importBookings(t, client, false, rows)
+	want := map[int32]bool{1: true, 2: true, 3: true, 4: true, 6: true, 17: true}
+	if len(res.GetErrors()) != len(want) {
+		t.Fatalf("expected errors on rows 1, 2, 3, 4, 6 and 17, got %v", res.GetErrors())
+	}
+	for _, rowErr := range res.GetErrors() {
+		if !want[rowErr.GetRow()] || rowErr.GetMessage() == "" {
+			t.Fatalf("unexpected error %v", rowErr)
+		}
+	}
+	if len(res.GetReceipts()) != 0 {
+		t.Fatalf("expected nothing to be booked, got %v", res.GetReceipts())
+	}
+	assertEmails(t, []string{"jane@example.com"}, adminEmails(t, client, &v1.ViewAdminDetailsRequest{}))
+
+	stream := client.ImportBookings(context.Background())
+	if _, err := stream.CloseAndReceive(); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for an empty import, got %v", err)
+	}
+}
+
+func TestImportBookingsNeedsAdmin(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)))
+	rows := []*v1.ImportBookingRow{importRow("Bob", server.DEFAULT_DEPARTURE_ID, v1.Section_SECTION_TYPE_UNSPECIFIED, 0)}
+
+	stream := newClient(srv, signJWT(t, "agent-7", "agent")).ImportBookings(context.Background())
+	stream.Send(&v1.ImportBookingsRequest{Rows: rows})
+	if _, err := stream.CloseAndReceive(); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied importing without the admin role, got %v", err)
+	}
+	admin := newClient(srv, signJWT(t, "alice", "admin"))
+	if seats := takenSeats(t, admin, ""); len(seats) != 0 {
+		t.Fatalf("expected nothing to be booked, got %v", seats)
+	}
+	if res := importBookings(t, admin, false, rows); len(res.GetReceipts()) != 1 {
+		t.Fatalf("expected an admin to import the booking, got %v", res)
+	}
+}
+
+func TestImportedBookingsSurviveRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.db")
+	departures := testDepartures(time.Now().Add(48 * time.Hour))
+	ledger := openTestBolt(t, path)
+	client := newTestClient(t, departures, server.WithLedger(ledger))
+	res := importBookings(t, client, false, []*v1.ImportBookingRow{
+		importRow("Ann", "morning", v1.Section_SECTION_TYPE_UNSPECIFIED, 0),
+		importRow("Bob", "evening", v1.Section_SECTION_TYPE_UNSPECIFIED, 0),
+	})
+
+	// The bbolt projection indexes each imported booking
+	records, err := ledger.BookingsByDeparture("evening")
+	if err != nil || len(records) != 1 || records[0].GetId() != res.GetReceipts()[1].GetBookingId() {
+		t.Fatalf("expected Bob's booking on the evening train, got %v (%v)", records, err)
+	}
+	ledger.Close()
+
+	restored := newTestClient(t, departures, server.WithLedger(openTestBolt(t, path)))
+	emails, _ := searchPassengers(t, restored, &v1.SearchPassengersRequest{Query: "group"})
+	assertEmails(t, []string{"Ann@example.com", "Bob@example.com"}, emails)
+}
@@ -48,16 +48,52 @@ const (
 	// TrainTicketingServiceModifySeatProcedure is the fully-qualified name of the
 	// TrainTicketingService's ModifySeat RPC.
 	TrainTicketingServiceModifySeatProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ModifySeat"
+	// TrainTicketingServiceCancelBookingProcedure is the fully-qualified name of the
+	// TrainTicketingService's CancelBooking RPC.
+	TrainTicketingServiceCancelBookingProcedure = "/proto.train_ticketing.v1.TrainTicketingService/CancelBooking"
+	// TrainTicketingServiceExchangeTicketProcedure is the fully-qualified name of the
+	// TrainTicketingService's ExchangeTicket RPC.
+	TrainTicketingServiceExchangeTicketProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ExchangeTicket"
+	// TrainTicketingServiceSearchPassengersProcedure is the fully-qualified name of the
+	// TrainTicketingService's SearchPassengers RPC.
+	TrainTicketingServiceSearchPassengersProcedure = "/proto.train_ticketing.v1.TrainTicketingService/SearchPassengers"
+	// TrainTicketingServiceExportManifestProcedure is the fully-qualified name of the
+	// TrainTicketingService's ExportManifest RPC.
+	TrainTicketingServiceExportManifestProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ExportManifest"
+	// TrainTicketingServiceImportBookingsProcedure is the fully-qualified name of the
+	// TrainTicketingService's ImportBookings RPC.
+	TrainTicketingServiceImportBookingsProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ImportBookings"
+	// TrainTicketingServiceViewSeatMapProcedure is the fully-qualified name of the
+	// TrainTicketingService's ViewSeatMap RPC.
+	TrainTicketingServiceViewSeatMapProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ViewSeatMap"
+	// TrainTicketingServiceListAuditEventsProcedure is the fully-qualified name of the
+	// TrainTicketingService's ListAuditEvents RPC.
+	TrainTicketingServiceListAuditEventsProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ListAuditEvents"
+	// TrainTicketingServiceListBookingReviewsProcedure is the fully-qualified name of the
+	// TrainTicketingService's ListBookingReviews RPC.
+	TrainTicketingServiceListBookingReviewsProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ListBookingReviews"
+	// TrainTicketingServiceReviewBookingProcedure is the fully-qualified name of the
+	// TrainTicketingService's ReviewBooking RPC.
+	TrainTicketingServiceReviewBookingProcedure = "/proto.train_ticketing.v1.TrainTicketingService/ReviewBooking"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	trainTicketingServiceServiceDescriptor                = v1.File_proto_train_ticketing_v1_ticketing_proto.Services().ByName("TrainTicketingService")
-	trainTicketingServicePurchaseTicketMethodDescriptor   = trainTicketingServiceServiceDescriptor.Methods().ByName("PurchaseTicket")
-	trainTicketingServiceViewReceiptMethodDescriptor      = trainTicketingServiceServiceDescriptor.Methods().ByName("ViewReceipt")
-	trainTicketingServiceViewAdminDetailsMethodDescriptor = trainTicketingServiceServiceDescriptor.Methods().ByName("ViewAdminDetails")
-	trainTicketingServiceRemoveUserMethodDescriptor       = trainTicketingServiceServiceDescriptor.Methods().ByName("RemoveUser")
-	trainTicketingServiceModifySeatMethodDescriptor       = trainTicketingServiceServiceDescriptor.Methods().ByName("ModifySeat")
+	trainTicketingServiceServiceDescriptor                  = v1.File_proto_train_ticketing_v1_ticketing_proto.Services().ByName("TrainTicketingService")
+	trainTicketingServicePurchaseTicketMethodDescriptor     = trainTicketingServiceServiceDescriptor.Methods().ByName("PurchaseTicket")
+	trainTicketingServiceViewReceiptMethodDescriptor        = trainTicketingServiceServiceDescriptor.Methods().ByName("ViewReceipt")
+	trainTicketingServiceViewAdminDetailsMethodDescriptor   = trainTicketingServiceServiceDescriptor.Methods().ByName("ViewAdminDetails")
+	trainTicketingServiceRemoveUserMethodDescriptor         = trainTicketingServiceServiceDescriptor.Methods().ByName("RemoveUser")
+	trainTicketingServiceModifySeatMethodDescriptor         = trainTicketingServiceServiceDescriptor.Methods().ByName("ModifySeat")
+	trainTicketingServiceCancelBookingMethodDescriptor      = trainTicketingServiceServiceDescriptor.Methods().ByName("CancelBooking")
+	trainTicketingServiceExchangeTicketMethodDescriptor     = trainTicketingServiceServiceDescriptor.Methods().ByName("ExchangeTicket")
+	trainTicketingServiceSearchPassengersMethodDescriptor   = trainTicketingServiceServiceDescriptor.Methods().ByName("SearchPassengers")
+	trainTicketingServiceExportManifestMethodDescriptor     = trainTicketingServiceServiceDescriptor.Methods().ByName("ExportManifest")
+	trainTicketingServiceImportBookingsMethodDescriptor     = trainTicketingServiceServiceDescriptor.Methods().ByName("ImportBookings")
+	trainTicketingServiceViewSeatMapMethodDescriptor        = trainTicketingServiceServiceDescriptor.Methods().ByName("ViewSeatMap")
+	trainTicketingServiceListAuditEventsMethodDescriptor    = trainTicketingServiceServiceDescriptor.Methods().ByName("ListAuditEvents")
+	trainTicketingServiceListBookingReviewsMethodDescriptor = trainTicketingServiceServiceDescriptor.Methods().ByName("ListBookingReviews")
+	trainTicketingServiceReviewBookingMethodDescriptor      = trainTicketingServiceServiceDescriptor.Methods().ByName("ReviewBooking")
 )
 
 // TrainTicketingServiceClient is a client for the proto.train_ticketing.v1.TrainTicketingService
@@ -68,6 +104,15 @@ type TrainTicketingServiceClient interface {
 	ViewAdminDetails(context.Context, *connect.Request[v1.ViewAdminDetailsRequest]) (*connect.Response[v1.ViewAdminDetailsResponse], error)
 	RemoveUser(context.Context, *connect.Request[v1.RemoveUserRequest]) (*connect.Response[v1.RemoveUserResponse], error)
 	ModifySeat(context.Context, *connect.Request[v1.ModifySeatRequest]) (*connect.Response[v1.ModifySeatResponse], error)
+	CancelBooking(context.Context, *connect.Request[v1.CancelBookingRequest]) (*connect.Response[v1.CancelBookingResponse], error)
+	ExchangeTicket(context.Context, *connect.Request[v1.ExchangeTicketRequest]) (*connect.Response[v1.ExchangeTicketResponse], error)
+	SearchPassengers(context.Context, *connect.Request[v1.SearchPassengersRequest]) (*connect.Response[v1.SearchPassengersResponse], error)
+	ExportManifest(context.Context, *connect.Request[v1.ExportManifestRequest]) (*connect.ServerStreamForClient[v1.ExportManifestResponse], error)
+	ImportBookings(context.Context) *connect.ClientStreamForClient[v1.ImportBookingsRequest, v1.ImportBookingsResponse]
+	ViewSeatMap(context.Context, *connect.Request[v1.ViewSeatMapRequest]) (*connect.Response[v1.ViewSeatMapResponse], error)
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
+	ListBookingReviews(context.Context, *connect.Request[v1.ListBookingReviewsRequest]) (*connect.Response[v1.ListBookingReviewsResponse], error)
+	ReviewBooking(context.Context, *connect.Request[v1.ReviewBookingRequest]) (*connect.Response[v1.ReviewBookingResponse], error)
 }
 
 // NewTrainTicketingServiceClient constructs a client for the
@@ -111,16 +156,79 @@ func NewTrainTicketingServiceClient(httpClient connect.HTTPClient, baseURL strin
 			connect.WithSchema(trainTicketingServiceModifySeatMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		cancelBooking: connect.NewClient[v1.CancelBookingRequest, v1.CancelBookingResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceCancelBookingProcedure,
+			connect.WithSchema(trainTicketingServiceCancelBookingMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exchangeTicket: connect.NewClient[v1.ExchangeTicketRequest, v1.ExchangeTicketResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceExchangeTicketProcedure,
+			connect.WithSchema(trainTicketingServiceExchangeTicketMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		searchPassengers: connect.NewClient[v1.SearchPassengersRequest, v1.SearchPassengersResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceSearchPassengersProcedure,
+			connect.WithSchema(trainTicketingServiceSearchPassengersMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exportManifest: connect.NewClient[v1.ExportManifestRequest, v1.ExportManifestResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceExportManifestProcedure,
+			connect.WithSchema(trainTicketingServiceExportManifestMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		importBookings: connect.NewClient[v1.ImportBookingsRequest, v1.ImportBookingsResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceImportBookingsProcedure,
+			connect.WithSchema(trainTicketingServiceImportBookingsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		viewSeatMap: connect.NewClient[v1.ViewSeatMapRequest, v1.ViewSeatMapResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceViewSeatMapProcedure,
+			connect.WithSchema(trainTicketingServiceViewSeatMapMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceListAuditEventsProcedure,
+			connect.WithSchema(trainTicketingServiceListAuditEventsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listBookingReviews: connect.NewClient[v1.ListBookingReviewsRequest, v1.ListBookingReviewsResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceListBookingReviewsProcedure,
+			connect.WithSchema(trainTicketingServiceListBookingReviewsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		reviewBooking: connect.NewClient[v1.ReviewBookingRequest, v1.ReviewBookingResponse](
+			httpClient,
+			baseURL+TrainTicketingServiceReviewBookingProcedure,
+			connect.WithSchema(trainTicketingServiceReviewBookingMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // trainTicketingServiceClient implements TrainTicketingServiceClient.
 type trainTicketingServiceClient struct {
-	purchaseTicket   *connect.Client[v1.PurchaseTicketRequest, v1.PurchaseTicketResponse]
-	viewReceipt      *connect.Client[v1.ViewReceiptRequest, v1.ViewReceiptResponse]
-	viewAdminDetails *connect.Client[v1.ViewAdminDetailsRequest, v1.ViewAdminDetailsResponse]
-	removeUser       *connect.Client[v1.RemoveUserRequest, v1.RemoveUserResponse]
-	modifySeat       *connect.Client[v1.ModifySeatRequest, v1.ModifySeatResponse]
+	purchaseTicket     *connect.Client[v1.PurchaseTicketRequest, v1.PurchaseTicketResponse]
+	viewReceipt        *connect.Client[v1.ViewReceiptRequest, v1.ViewReceiptResponse]
+	viewAdminDetails   *connect.Client[v1.ViewAdminDetailsRequest, v1.ViewAdminDetailsResponse]
+	removeUser         *connect.Client[v1.RemoveUserRequest, v1.RemoveUserResponse]
+	modifySeat         *connect.Client[v1.ModifySeatRequest, v1.ModifySeatResponse]
+	cancelBooking      *connect.Client[v1.CancelBookingRequest, v1.CancelBookingResponse]
+	exchangeTicket     *connect.Client[v1.ExchangeTicketRequest, v1.ExchangeTicketResponse]
+	searchPassengers   *connect.Client[v1.SearchPassengersRequest, v1.SearchPassengersResponse]
+	exportManifest     *connect.Client[v1.ExportManifestRequest, v1.ExportManifestResponse]
+	importBookings     *connect.Client[v1.ImportBookingsRequest, v1.ImportBookingsResponse]
+	viewSeatMap        *connect.Client[v1.ViewSeatMapRequest, v1.ViewSeatMapResponse]
+	listAuditEvents    *connect.Client[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse]
+	listBookingReviews *connect.Client[v1.ListBookingReviewsRequest, v1.ListBookingReviewsResponse]
+	reviewBooking      *connect.Client[v1.ReviewBookingRequest, v1.ReviewBookingResponse]
 }
 
 // PurchaseTicket calls proto.train_ticketing.v1.TrainTicketingService.PurchaseTicket.
@@ -148,6 +256,51 @@ func (c *trainTicketingServiceClient) ModifySeat(ctx context.Context, req *conne
 	return c.modifySeat.CallUnary(ctx, req)
 }
 
+// CancelBooking calls proto.train_ticketing.v1.TrainTicketingService.CancelBooking.
+func (c *trainTicketingServiceClient) CancelBooking(ctx context.Context, req *connect.Request[v1.CancelBookingRequest]) (*connect.Response[v1.CancelBookingResponse], error) {
+	return c.cancelBooking.CallUnary(ctx, req)
+}
+
+// ExchangeTicket calls proto.train_ticketing.v1.TrainTicketingService.ExchangeTicket.
+func (c *trainTicketingServiceClient) ExchangeTicket(ctx context.Context, req *connect.Request[v1.ExchangeTicketRequest]) (*connect.Response[v1.ExchangeTicketResponse], error) {
+	return c.exchangeTicket.CallUnary(ctx, req)
+}
+
+// SearchPassengers calls proto.train_ticketing.v1.TrainTicketingService.SearchPassengers.
+func (c *trainTicketingServiceClient) SearchPassengers(ctx context.Context, req *connect.Request[v1.SearchPassengersRequest]) (*connect.Response[v1.SearchPassengersResponse], error) {
+	return c.searchPassengers.CallUnary(ctx, req)
+}
+
+// ExportManifest calls proto.train_ticketing.v1.TrainTicketingService.ExportManifest.
+func (c *trainTicketingServiceClient) ExportManifest(ctx context.Context, req *connect.Request[v1.ExportManifestRequest]) (*connect.ServerStreamForClient[v1.ExportManifestResponse], error) {
+	return c.exportManifest.CallServerStream(ctx, req)
+}
+
+// ImportBookings calls proto.train_ticketing.v1.TrainTicketingService.ImportBookings.
+func (c *trainTicketingServiceClient) ImportBookings(ctx context.Context) *connect.ClientStreamForClient[v1.ImportBookingsRequest, v1.ImportBookingsResponse] {
+	return c.importBookings.CallClientStream(ctx)
+}
+
+// ViewSeatMap calls proto.train_ticketing.v1.TrainTicketingService.ViewSeatMap.
+func (c *trainTicketingServiceClient) ViewSeatMap(ctx context.Context, req *connect.Request[v1.ViewSeatMapRequest]) (*connect.Response[v1.ViewSeatMapResponse], error) {
+	return c.viewSeatMap.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls proto.train_ticketing.v1.TrainTicketingService.ListAuditEvents.
+func (c *trainTicketingServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
+// ListBookingReviews calls proto.train_ticketing.v1.TrainTicketingService.ListBookingReviews.
+func (c *trainTicketingServiceClient) ListBookingReviews(ctx context.Context, req *connect.Request[v1.ListBookingReviewsRequest]) (*connect.Response[v1.ListBookingReviewsResponse], error) {
+	return c.listBookingReviews.CallUnary(ctx, req)
+}
+
+// ReviewBooking calls proto.train_ticketing.v1.TrainTicketingService.ReviewBooking.
+func (c *trainTicketingServiceClient) ReviewBooking(ctx context.Context, req *connect.Request[v1.ReviewBookingRequest]) (*connect.Response[v1.ReviewBookingResponse], error) {
+	return c.reviewBooking.CallUnary(ctx, req)
+}
+
 // TrainTicketingServiceHandler is an implementation of the
 // proto.train_ticketing.v1.TrainTicketingService service.
 type TrainTicketingServiceHandler interface {
@@ -156,6 +309,15 @@ type TrainTicketingServiceHandler interface {
 	ViewAdminDetails(context.Context, *connect.Request[v1.ViewAdminDetailsRequest]) (*connect.Response[v1.ViewAdminDetailsResponse], error)
 	RemoveUser(context.Context, *connect.Request[v1.RemoveUserRequest]) (*connect.Response[v1.RemoveUserResponse], error)
 	ModifySeat(context.Context, *connect.Request[v1.ModifySeatRequest]) (*connect.Response[v1.ModifySeatResponse], error)
+	CancelBooking(context.Context, *connect.Request[v1.CancelBookingRequest]) (*connect.Response[v1.CancelBookingResponse], error)
+	ExchangeTicket(context.Context, *connect.Request[v1.ExchangeTicketRequest]) (*connect.Response[v1.ExchangeTicketResponse], error)
+	SearchPassengers(context.Context, *connect.Request[v1.SearchPassengersRequest]) (*connect.Response[v1.SearchPassengersResponse], error)
+	ExportManifest(context.Context, *connect.Request[v1.ExportManifestRequest], *connect.ServerStream[v1.ExportManifestResponse]) error
+	ImportBookings(context.Context, *connect.ClientStream[v1.ImportBookingsRequest]) (*connect.Response[v1.ImportBookingsResponse], error)
+	ViewSeatMap(context.Context, *connect.Request[v1.ViewSeatMapRequest]) (*connect.Response[v1.ViewSeatMapResponse], error)
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
+	ListBookingReviews(context.Context, *connect.Request[v1.ListBookingReviewsRequest]) (*connect.Response[v1.ListBookingReviewsResponse], error)
+	ReviewBooking(context.Context, *connect.Request[v1.ReviewBookingRequest]) (*connect.Response[v1.ReviewBookingResponse], error)
 }
 
 // NewTrainTicketingServiceHandler builds an HTTP handler from the service implementation. It
@@ -194,6 +356,60 @@ func NewTrainTicketingServiceHandler(svc TrainTicketingServiceHandler, opts ...c
 		connect.WithSchema(trainTicketingServiceModifySeatMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	trainTicketingServiceCancelBookingHandler := connect.NewUnaryHandler(
+		TrainTicketingServiceCancelBookingProcedure,
+		svc.CancelBooking,
+		connect.WithSchema(trainTicketingServiceCancelBookingMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceExchangeTicketHandler := connect.NewUnaryHandler(
+		TrainTicketingServiceExchangeTicketProcedure,
+		svc.ExchangeTicket,
+		connect.WithSchema(trainTicketingServiceExchangeTicketMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceSearchPassengersHandler := connect.NewUnaryHandler(
+		TrainTicketingServiceSearchPassengersProcedure,
+		svc.SearchPassengers,
+		connect.WithSchema(trainTicketingServiceSearchPassengersMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceExportManifestHandler := connect.NewServerStreamHandler(
+		TrainTicketingServiceExportManifestProcedure,
+		svc.ExportManifest,
+		connect.WithSchema(trainTicketingServiceExportManifestMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceImportBookingsHandler := connect.NewClientStreamHandler(
+		TrainTicketingServiceImportBookingsProcedure,
+		svc.ImportBookings,
+		connect.WithSchema(trainTicketingServiceImportBookingsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceViewSeatMapHandler := connect.NewUnaryHandler(
+		TrainTicketingServiceViewSeatMapProcedure,
+		svc.ViewSeatMap,
+		connect.WithSchema(trainTicketingServiceViewSeatMapMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		TrainTicketingServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(trainTicketingServiceListAuditEventsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceListBookingReviewsHandler := connect.NewUnaryHandler(
+		TrainTicketingServiceListBookingReviewsProcedure,
+		svc.ListBookingReviews,
+		connect.WithSchema(trainTicketingServiceListBookingReviewsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	trainTicketingServiceReviewBookingHandler := connect.NewUnaryHandler(
+		TrainTicketingServiceReviewBookingProcedure,
+		svc.ReviewBooking,
+		connect.WithSchema(trainTicketingServiceReviewBookingMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/proto.train_ticketing.v1.TrainTicketingService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TrainTicketingServicePurchaseTicketProcedure:
@@ -206,6 +422,24 @@ func NewTrainTicketingServiceHandler(svc TrainTicketingServiceHandler, opts ...c
 			trainTicketingServiceRemoveUserHandler.ServeHTTP(w, r)
 		case TrainTicketingServiceModifySeatProcedure:
 			trainTicketingServiceModifySeatHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceCancelBookingProcedure:
+			trainTicketingServiceCancelBookingHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceExchangeTicketProcedure:
+			trainTicketingServiceExchangeTicketHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceSearchPassengersProcedure:
+			trainTicketingServiceSearchPassengersHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceExportManifestProcedure:
+			trainTicketingServiceExportManifestHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceImportBookingsProcedure:
+			trainTicketingServiceImportBookingsHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceViewSeatMapProcedure:
+			trainTicketingServiceViewSeatMapHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceListAuditEventsProcedure:
+			trainTicketingServiceListAuditEventsHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceListBookingReviewsProcedure:
+			trainTicketingServiceListBookingReviewsHandler.ServeHTTP(w, r)
+		case TrainTicketingServiceReviewBookingProcedure:
+			trainTicketingServiceReviewBookingHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -234,3 +468,39 @@ func (UnimplementedTrainTicketingServiceHandler) RemoveUser(context.Context, *co
 func (UnimplementedTrainTicketingServiceHandler) ModifySeat(context.Context, *connect.Request[v1.ModifySeatRequest]) (*connect.Response[v1.ModifySeatResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ModifySeat is not implemented"))
 }
+
+func (UnimplementedTrainTicketingServiceHandler) CancelBooking(context.Context, *connect.Request[v1.CancelBookingRequest]) (*connect.Response[v1.CancelBookingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.CancelBooking is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) ExchangeTicket(context.Context, *connect.Request[v1.ExchangeTicketRequest]) (*connect.Response[v1.ExchangeTicketResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ExchangeTicket is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) SearchPassengers(context.Context, *connect.Request[v1.SearchPassengersRequest]) (*connect.Response[v1.SearchPassengersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.SearchPassengers is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) ExportManifest(context.Context, *connect.Request[v1.ExportManifestRequest], *connect.ServerStream[v1.ExportManifestResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ExportManifest is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) ImportBookings(context.Context, *connect.ClientStream[v1.ImportBookingsRequest]) (*connect.Response[v1.ImportBookingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ImportBookings is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) ViewSeatMap(context.Context, *connect.Request[v1.ViewSeatMapRequest]) (*connect.Response[v1.ViewSeatMapResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ViewSeatMap is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ListAuditEvents is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) ListBookingReviews(context.Context, *connect.Request[v1.ListBookingReviewsRequest]) (*connect.Response[v1.ListBookingReviewsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ListBookingReviews is not implemented"))
+}
+
+func (UnimplementedTrainTicketingServiceHandler) ReviewBooking(context.Context, *connect.Request[v1.ReviewBookingRequest]) (*connect.Response[v1.ReviewBookingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.train_ticketing.v1.TrainTicketingService.ReviewBooking is not implemented"))
+}
@@ -0,0 +1,471 @@
+package ticketing
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Ledger is an append-only log of booking events. Every change to seats,
+// bookings and users is appended to the ledger before it is applied, so
+// replaying a ledger rebuilds the handler's state.
+type Ledger interface {
+	// Append records an event. Events must not be modified once appended.
+	Append(event *v1.LedgerEvent) error
+	// Replay calls fn for every event appended so far, oldest first, stopping
+	// at the first error.
+	Replay(fn func(*v1.LedgerEvent) error) error
+}
+
+// LEDGER_CONFLICT_RETRIES is how many times an event that is still valid is
+// recorded before giving up when other handlers keep appending first.
+const LEDGER_CONFLICT_RETRIES = 5
+
+// ErrLedgerConflict is returned by a SharedLedger when another handler appended
+// an event with the same sequence number first.
+var ErrLedgerConflict = errors.New("ledger changed by another handler")
+
+// SharedLedger is implemented by ledgers that several handlers append to at
+// once, such as PostgresLedger. Each handler applies the events the others
+// have recorded before reading or changing bookings, and Append fails with
+// ErrLedgerConflict when the handler hadn't seen every event yet.
+type SharedLedger interface {
+	Ledger
+	// ReplaySince calls fn for every event after sequence, oldest first,
+	// stopping at the first error.
+	ReplaySince(sequence int64, fn func(*v1.LedgerEvent) error) error
+}
+
+// WithLedger sets the ledger booking events are written to. Events already in
+// the ledger are replayed when the handler is created, so the ledger must have
+// been written by a handler with the same departures. By default events are
+// kept in a MemoryLedger.
+func WithLedger(ledger Ledger) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.ledger = ledger
+	}
+}
+
+// MemoryLedger keeps events in memory.
+type MemoryLedger struct {
+	mu     sync.Mutex
+	events []*v1.LedgerEvent
+}
+
+// Append implements Ledger.
+func (l *MemoryLedger) Append(event *v1.LedgerEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+	return nil
+}
+
+// Replay implements Ledger.
+func (l *MemoryLedger) Replay(fn func(*v1.LedgerEvent) error) error {
+	for _, event := range l.Events() {
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Events returns every event appended so far.
+func (l *MemoryLedger) Events() []*v1.LedgerEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]*v1.LedgerEvent(nil), l.events...)
+}
+
+// FileLedger appends events to a file, one JSON object per line, so the
+// history can be inspected with ordinary text tools.
+type FileLedger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileLedger opens the ledger at path, creating it if it doesn't exist.
+func OpenFileLedger(path string) (*FileLedger, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger: %w", err)
+	}
+	return &FileLedger{file: file}, nil
+}
+
+// Append implements Ledger.
+func (l *FileLedger) Append(event *v1.LedgerEvent) error {
+	line, err := protojson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode ledger event: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.file.Write(append(line, '\n'))
+	return err
+}
+
+// Replay implements Ledger.
+func (l *FileLedger) Replay(fn func(*v1.LedgerEvent) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(l.file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		event := &v1.LedgerEvent{}
+		if err := protojson.Unmarshal(scanner.Bytes(), event); err != nil {
+			return fmt.Errorf("ledger line %d: %w", line, err)
+		}
+		if err := fn(event); err != nil {
+			return fmt.Errorf("ledger line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Close closes the ledger file.
+func (l *FileLedger) Close() error {
+	return l.file.Close()
+}
+
+// record appends an event to the ledger and then applies it. The caller must
+// hold h.mu for reading, along with the locks of the departures the event
+// changes. h.mu is released while the event is appended, so requests on other
+// departures aren't held up, and is held again when record returns.
+func (h *MyTrainTicketingServiceHandler) record(ctx context.Context, event *v1.LedgerEvent) error {
+	h.mu.RUnlock()
+	defer h.mu.RLock()
+	return h.appendEvent(ctx, event)
+}
+
+// appendEvent is record for callers that don't hold h.mu. h.ledgerMu keeps
+// the events in sequence, and h.mu is only locked while the event is applied.
+func (h *MyTrainTicketingServiceHandler) appendEvent(ctx context.Context, event *v1.LedgerEvent) error {
+	h.ledgerMu.Lock()
+	defer h.ledgerMu.Unlock()
+
+	event.Sequence = h.sequence + 1
+	event.OccurredAt = timestamppb.New(h.now())
+	_, span := h.startSpan(ctx, "ledger.Append",
+		attribute.String("ledger.event", eventName(event)),
+		attribute.Int64("ledger.sequence", event.GetSequence()),
+	)
+	err := h.ledger.Append(event)
+	endSpan(span, err)
+	if err != nil {
+		if errors.Is(err, ErrLedgerConflict) {
+			// Catch up so the request can be retried against the latest bookings
+			if err := h.catchUp(ctx); err != nil {
+				return err
+			}
+			return connect.NewError(connect.CodeAborted, fmt.Errorf("bookings were changed at the same time, please retry: %w", err))
+		}
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to record booking event: %w", err))
+	}
+
+	h.mu.Lock()
+	h.noteBefore(ctx, event)
+	err = h.apply(event)
+	if err == nil {
+		h.noteAfter(ctx, event)
+		h.countSales(event)
+	}
+	h.mu.Unlock()
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	h.maybeSnapshot(ctx)
+	return nil
+}
+
+// recordOwn is record for events about a booking that is still being
+// purchased. Only the handler making the purchase changes such a booking, so
+// the event is recorded again if other handlers sharing the ledger got in
+// first.
+func (h *MyTrainTicketingServiceHandler) recordOwn(ctx context.Context, event *v1.LedgerEvent) error {
+	for attempt := 1; ; attempt++ {
+		err := h.record(ctx, event)
+		if !errors.Is(err, ErrLedgerConflict) || attempt == LEDGER_CONFLICT_RETRIES {
+			return err
+		}
+	}
+}
+
+// sync applies the events other handlers sharing the ledger have recorded
+// since this one last looked. The caller must not hold h.mu.
+func (h *MyTrainTicketingServiceHandler) sync(ctx context.Context) error {
+	if _, ok := h.ledger.(SharedLedger); !ok {
+		return nil
+	}
+	h.ledgerMu.Lock()
+	defer h.ledgerMu.Unlock()
+	return h.catchUp(ctx)
+}
+
+// catchUp is sync for callers that hold h.ledgerMu.
+func (h *MyTrainTicketingServiceHandler) catchUp(ctx context.Context) error {
+	shared, ok := h.ledger.(SharedLedger)
+	if !ok {
+		return nil
+	}
+	_, span := h.startSpan(ctx, "ledger.ReplaySince", attribute.Int64("ledger.sequence", h.sequence))
+	err := shared.ReplaySince(h.sequence, func(event *v1.LedgerEvent) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return h.applyNext(event)
+	})
+	endSpan(span, err)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to catch up with ledger: %w", err))
+	}
+	return nil
+}
+
+// applyNext applies an event read back from the ledger, skipping events that
+// have already been applied.
+func (h *MyTrainTicketingServiceHandler) applyNext(event *v1.LedgerEvent) error {
+	if event.GetSequence() <= h.sequence {
+		return nil
+	}
+	if event.GetSequence() != h.sequence+1 {
+		return fmt.Errorf("expected event %d, found event %d", h.sequence+1, event.GetSequence())
+	}
+	return h.apply(event)
+}
+
+// apply folds a single event into the handler's state. It is used both for
+// new events and when replaying the ledger at startup.
+func (h *MyTrainTicketingServiceHandler) apply(event *v1.LedgerEvent) error {
+	at := event.GetOccurredAt().AsTime()
+
+	switch e := event.GetEvent().(type) {
+	case *v1.LedgerEvent_SeatHeld:
+		ticket := proto.Clone(e.SeatHeld.GetTicket()).(*v1.Ticket)
+		seat, err := h.eventSeat(ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber())
+		if err != nil {
+			return err
+		}
+		seat.User = ticket.GetUser()
+		ticket.Seat = seat
+		status := v1.BookingStatus_BOOKING_STATUS_HELD
+		if e.SeatHeld.GetReviewReason() != "" {
+			status = v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW
+		}
+		h.addBooking(&booking{
+			id:            e.SeatHeld.GetBookingId(),
+			seat:          seat,
+			ticket:        ticket,
+			status:        status,
+			purchasedAt:   at,
+			paymentMethod: e.SeatHeld.GetPaymentMethod(),
+			version:       1,
+			account:       e.SeatHeld.GetAccount(),
+			clientIP:      e.SeatHeld.GetClientIp(),
+			fingerprint:   e.SeatHeld.GetPaymentFingerprint(),
+			reviewReason:  e.SeatHeld.GetReviewReason(),
+		})
+
+	case *v1.LedgerEvent_HoldReleased:
+		b, err := h.eventBooking(e.HoldReleased.GetBookingId())
+		if err != nil {
+			return err
+		}
+		b.seat.User = nil
+		h.removeBooking(b)
+
+	case *v1.LedgerEvent_BookingConfirmed:
+		b, err := h.eventBooking(e.BookingConfirmed.GetBookingId())
+		if err != nil {
+			return err
+		}
+		b.status = v1.BookingStatus_BOOKING_STATUS_CONFIRMED
+		b.payments = e.BookingConfirmed.GetPayments()
+		h.users[b.ticket.GetUser().GetEmail()] = b.ticket.GetUser()
+
+	case *v1.LedgerEvent_DiscountRedeemed:
+		h.redemptions[e.DiscountRedeemed.GetDiscountCode()]++
+
+	case *v1.LedgerEvent_SeatModified:
+		b, err := h.eventBooking(e.SeatModified.GetBookingId())
+		if err != nil {
+			return err
+		}
+		seat, err := h.eventSeat(b.ticket.GetDepartureId(), e.SeatModified.GetSeatNumber())
+		if err != nil && seat != b.seat {
+			return err
+		}
+		user := b.seat.GetUser()
+		b.seat.User = nil
+		seat.User = user
+		b.seat = seat
+		b.ticket.Seat = seat
+		b.version++
+
+	case *v1.LedgerEvent_BookingCancelled:
+		b, err := h.eventBooking(e.BookingCancelled.GetBookingId())
+		if err != nil {
+			return err
+		}
+		h.release(b, v1.BookingStatus_BOOKING_STATUS_CANCELLED)
+		b.cancelledAt = at
+		b.refundAmount = e.BookingCancelled.GetRefundAmount()
+		b.payments = e.BookingCancelled.GetPayments()
+
+	case *v1.LedgerEvent_UserRemoved:
+		delete(h.users, e.UserRemoved.GetEmail())
+
+	case *v1.LedgerEvent_BookingExchanged:
+		old, err := h.eventBooking(e.BookingExchanged.GetBookingId())
+		if err != nil {
+			return err
+		}
+		ticket := proto.Clone(e.BookingExchanged.GetTicket()).(*v1.Ticket)
+		seat, err := h.eventSeat(ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber())
+		if err != nil {
+			return err
+		}
+		seat.User = old.seat.GetUser()
+		ticket.Seat = seat
+		h.addBooking(&booking{
+			id:            e.BookingExchanged.GetNewBookingId(),
+			seat:          seat,
+			ticket:        ticket,
+			status:        v1.BookingStatus_BOOKING_STATUS_CONFIRMED,
+			purchasedAt:   at,
+			previousIDs:   append(append([]string(nil), old.previousIDs...), old.id),
+			account:       old.account,
+			paymentMethod: old.paymentMethod,
+			payments:      e.BookingExchanged.GetPayments(),
+			version:       1,
+		})
+		h.release(old, v1.BookingStatus_BOOKING_STATUS_EXCHANGED)
+		old.payments = nil
+
+	case *v1.LedgerEvent_BookingsImported:
+		for _, imported := range e.BookingsImported.GetBookings() {
+			ticket := proto.Clone(imported.GetTicket()).(*v1.Ticket)
+			seat, err := h.eventSeat(ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber())
+			if err != nil {
+				return err
+			}
+			seat.User = ticket.GetUser()
+			ticket.Seat = seat
+			h.addBooking(&booking{
+				id:          imported.GetBookingId(),
+				seat:        seat,
+				ticket:      ticket,
+				status:      v1.BookingStatus_BOOKING_STATUS_CONFIRMED,
+				purchasedAt: at,
+				version:     1,
+			})
+			h.users[ticket.GetUser().GetEmail()] = ticket.GetUser()
+		}
+
+	case *v1.LedgerEvent_BookingRejected:
+		b, err := h.eventBooking(e.BookingRejected.GetBookingId())
+		if err != nil {
+			return err
+		}
+		h.release(b, v1.BookingStatus_BOOKING_STATUS_REJECTED)
+
+	default:
+		return fmt.Errorf("unknown ledger event %d", event.GetSequence())
+	}
+
+	// Keep the passenger index in step with the bookings the event touched
+	for _, id := range eventBookingIDs(event) {
+		h.passengers.update(id, h.bookings[id])
+	}
+
+	h.sequence = event.GetSequence()
+	return nil
+}
+
+// eventBookingIDs returns the IDs of the bookings an event changes.
+func eventBookingIDs(event *v1.LedgerEvent) []string {
+	switch e := event.GetEvent().(type) {
+	case *v1.LedgerEvent_SeatHeld:
+		return []string{e.SeatHeld.GetBookingId()}
+	case *v1.LedgerEvent_HoldReleased:
+		return []string{e.HoldReleased.GetBookingId()}
+	case *v1.LedgerEvent_BookingConfirmed:
+		return []string{e.BookingConfirmed.GetBookingId()}
+	case *v1.LedgerEvent_SeatModified:
+		return []string{e.SeatModified.GetBookingId()}
+	case *v1.LedgerEvent_BookingCancelled:
+		return []string{e.BookingCancelled.GetBookingId()}
+	case *v1.LedgerEvent_BookingExchanged:
+		return []string{e.BookingExchanged.GetBookingId(), e.BookingExchanged.GetNewBookingId()}
+	case *v1.LedgerEvent_BookingRejected:
+		return []string{e.BookingRejected.GetBookingId()}
+	case *v1.LedgerEvent_BookingsImported:
+		var ids []string
+		for _, imported := range e.BookingsImported.GetBookings() {
+			ids = append(ids, imported.GetBookingId())
+		}
+		return ids
+	}
+	return nil
+}
+
+// addBooking adds b to the bookings, and to those of its departure.
+func (h *MyTrainTicketingServiceHandler) addBooking(b *booking) {
+	h.bookings[b.id] = b
+	if d, ok := h.departures[b.ticket.GetDepartureId()]; ok {
+		d.bookings[b.id] = b
+	}
+}
+
+// removeBooking drops b from the bookings, and from those of its departure.
+func (h *MyTrainTicketingServiceHandler) removeBooking(b *booking) {
+	delete(h.bookings, b.id)
+	if d, ok := h.departures[b.ticket.GetDepartureId()]; ok {
+		delete(d.bookings, b.id)
+	}
+}
+
+// eventBooking returns the booking an event refers to.
+func (h *MyTrainTicketingServiceHandler) eventBooking(id string) (*booking, error) {
+	b, ok := h.bookings[id]
+	if !ok {
+		return nil, fmt.Errorf("booking %q not found", id)
+	}
+	return b, nil
+}
+
+// eventSeat returns the seat an event moves a user into. The seat is returned
+// alongside errNoSeats when it is already taken, so callers moving a user
+// within their own seat can ignore the error.
+func (h *MyTrainTicketingServiceHandler) eventSeat(departureID string, seatNumber int32) (*v1.Seat, error) {
+	d, ok := h.departures[departureID]
+	if !ok {
+		return nil, fmt.Errorf("departure %q not found", departureID)
+	}
+	seat := d.seat(seatNumber)
+	if seat == nil {
+		return nil, fmt.Errorf("seat %d not found on departure %q", seatNumber, departureID)
+	}
+	if seat.GetUser() != nil {
+		return seat, fmt.Errorf("seat %d on departure %q: %w", seatNumber, departureID, errNoSeats)
+	}
+	return seat, nil
+}
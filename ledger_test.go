@@ -0,0 +1,165 @@
+package ticketing_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// failingLedger fails to append the events fail picks, as a ledger whose
+// storage is down would.
+type failingLedger struct {
+	server.MemoryLedger
+	fail func(event *v1.LedgerEvent) bool
+}
+
+func (l *failingLedger) Append(event *v1.LedgerEvent) error {
+	if l.fail(event) {
+		return errors.New("disk full")
+	}
+	return l.MemoryLedger.Append(event)
+}
+
+func viewAdminSeats(t *testing.T, client ticketingv1.TrainTicketingServiceClient) []*v1.Seat {
+	t.Helper()
+	admin, err := client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+	if err != nil {
+		t.Fatalf("ViewAdminDetails failed: %v", err)
+	}
+	return admin.Msg.GetAdminView().GetSeats()
+}
+
+func TestLedgerReplayRestoresState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	departures := testDepartures(time.Now().Add(48 * time.Hour))
+
+	ledger, err := server.OpenFileLedger(path)
+	if err != nil {
+		t.Fatalf("OpenFileLedger failed: %v", err)
+	}
+	client := newTestClient(t, departures, server.WithLedger(ledger))
+
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	johnID := purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	maryID := purchaseTicket(t, client, "Mary", "Major", "mary@example.com", withDiscount("WOW1"))
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "Jane"},
+		NewSeatNumber: 12,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	if _, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: johnID})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if _, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   maryID,
+		DepartureId: "evening",
+	})); err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+	want := viewAdminSeats(t, client)
+	if err := ledger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// A new handler replaying the same file ends up in the same state
+	ledger, err = server.OpenFileLedger(path)
+	if err != nil {
+		t.Fatalf("OpenFileLedger failed: %v", err)
+	}
+	defer ledger.Close()
+	restored := newTestClient(t, departures, server.WithLedger(ledger))
+
+	got := viewAdminSeats(t, restored)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d seats after replay, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Fatalf("seat %d differs after replay: want %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	receipt, err := restored.ViewReceipt(context.Background(), connect.NewRequest(&v1.ViewReceiptRequest{
+		Ticket: &v1.Ticket{User: &v1.User{FirstName: "Mary", LastName: "Major", Email: "mary@example.com"}},
+	}))
+	if err != nil {
+		t.Fatalf("ViewReceipt failed: %v", err)
+	}
+	if ids := receipt.Msg.GetReceipt().GetPreviousBookingIds(); len(ids) != 1 || ids[0] != maryID {
+		t.Fatalf("expected exchange history to survive replay, got %v", ids)
+	}
+
+	// The restored handler keeps appending to the same ledger
+	purchaseTicket(t, restored, "Ann", "Lee", "ann@example.com")
+	if lines := countLines(t, path); lines != 12 {
+		t.Fatalf("expected 12 ledger events, got %d", lines)
+	}
+}
+
+func TestMemoryLedgerRecordsSeatHistory(t *testing.T) {
+	ledger := &server.MemoryLedger{}
+	client := newTestClient(t, server.WithLedger(ledger))
+
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "Jane"},
+		NewSeatNumber: 5,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	purchaseTicket(t, client, "John", "Doe", "john@example.com")
+
+	// Seat 1 was first held for Jane, then given to John once she moved to seat 5
+	var seatOne []string
+	for i, event := range ledger.Events() {
+		if event.GetSequence() != int64(i+1) {
+			t.Fatalf("expected sequence %d, got %d", i+1, event.GetSequence())
+		}
+		if held := event.GetSeatHeld(); held != nil && held.GetTicket().GetSeat().GetSeatNumber() == 1 {
+			seatOne = append(seatOne, held.GetTicket().GetUser().GetFirstName())
+		}
+	}
+	if len(seatOne) != 2 || seatOne[0] != "Jane" || seatOne[1] != "John" {
+		t.Fatalf("expected seat 1 to be held by Jane then John, got %v", seatOne)
+	}
+}
+
+func TestLedgerReplayRejectsInconsistentEvents(t *testing.T) {
+	ledger := &server.MemoryLedger{}
+	_ = ledger.Append(&v1.LedgerEvent{
+		Sequence: 1,
+		Event:    &v1.LedgerEvent_BookingConfirmed{BookingConfirmed: &v1.BookingConfirmed{BookingId: "missing"}},
+	})
+
+	if _, err := server.New(server.WithLedger(ledger)); err == nil {
+		t.Fatalf("expected replay of an unknown booking to fail")
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer file.Close()
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
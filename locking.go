@@ -0,0 +1,121 @@
+package ticketing
+
+import (
+	"context"
+	"sort"
+)
+
+// lockDepartures locks the departures with the given IDs and returns a
+// function that unlocks them. They are locked in order of ID, so requests
+// locking several departures can't deadlock. Unknown IDs are ignored. The
+// caller must not hold h.mu.
+func (h *MyTrainTicketingServiceHandler) lockDepartures(ids ...string) func() {
+	ids = append([]string(nil), ids...)
+	sort.Strings(ids)
+
+	var locked []*departure
+	for i, id := range ids {
+		d, ok := h.departures[id]
+		if !ok || (i > 0 && ids[i-1] == id) {
+			continue
+		}
+		d.mu.Lock()
+		locked = append(locked, d)
+	}
+	return func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			locked[i].mu.Unlock()
+		}
+	}
+}
+
+// lockBookings locks the departures of the bookings returned by find, along
+// with the departures named in extra, and takes a read lock on h.mu. It
+// returns those bookings with a function that unlocks everything. find is
+// called again once the locks are held, and the locks are taken again if its
+// answer moved to other departures in the meantime. The caller must not hold
+// h.mu.
+func (h *MyTrainTicketingServiceHandler) lockBookings(ctx context.Context, find func() ([]*booking, error), extra ...string) ([]*booking, func(), error) {
+	for {
+		// Find out which departures to lock
+		found, err := h.syncAndFind(ctx, find)
+		if err != nil {
+			return nil, nil, err
+		}
+		h.mu.RUnlock()
+		ids := append(bookingDepartures(found), extra...)
+		unlockDepartures := h.lockDepartures(ids...)
+
+		// Look again now that nothing else can change those departures
+		again, err := h.syncAndFind(ctx, find)
+		if err != nil {
+			unlockDepartures()
+			return nil, nil, err
+		}
+		if sameStrings(bookingDepartures(again), bookingDepartures(found)) {
+			return again, func() {
+				h.mu.RUnlock()
+				unlockDepartures()
+			}, nil
+		}
+		h.mu.RUnlock()
+		unlockDepartures()
+	}
+}
+
+// syncAndFind catches up with the ledger, takes a read lock on h.mu and calls
+// find. The read lock is only kept when find succeeds. The caller must not
+// hold h.mu.
+func (h *MyTrainTicketingServiceHandler) syncAndFind(ctx context.Context, find func() ([]*booking, error)) ([]*booking, error) {
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	found, err := find()
+	if err != nil {
+		h.mu.RUnlock()
+		return nil, err
+	}
+	return found, nil
+}
+
+// unlocked runs fn with h.mu released, for slow calls such as payments. The
+// caller must hold h.mu for reading, and the locks of the departures fn
+// depends on.
+func (h *MyTrainTicketingServiceHandler) unlocked(fn func()) {
+	h.mu.RUnlock()
+	defer h.mu.RLock()
+	fn()
+}
+
+// readLock catches up with other handlers sharing the ledger and then takes a
+// read lock on h.mu, so requests that only read don't block each other. The
+// caller must not hold h.mu, and must call h.mu.RUnlock once done.
+func (h *MyTrainTicketingServiceHandler) readLock(ctx context.Context) error {
+	if err := h.sync(ctx); err != nil {
+		return err
+	}
+	h.mu.RLock()
+	return nil
+}
+
+// bookingDepartures returns the sorted IDs of the departures bookings are on.
+func bookingDepartures(bookings []*booking) []string {
+	var ids []string
+	for _, b := range bookings {
+		ids = append(ids, b.ticket.GetDepartureId())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+package ticketing_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	server "github.com/parandor/ticketing"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// lockingOptions give a handler n departures, whose IDs are returned, and a
+// payment provider that takes latency to answer each call.
+func lockingOptions(n int, latency time.Duration) (server.Option, []string) {
+	morning := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	var departures []*v1.Departure
+	var ids []string
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("train-%d", i)
+		departures = append(departures, &v1.Departure{
+			Id:            id,
+			From:          "London",
+			To:            "Paris",
+			DepartureTime: timestamppb.New(morning.Add(time.Duration(i) * time.Hour)),
+			Fare:          20 + float32(i),
+		})
+		ids = append(ids, id)
+	}
+	return withOptions(
+		server.WithDepartures(departures...),
+		server.WithPaymentProvider(&server.FakePaymentProvider{Latency: latency}),
+	), ids
+}
+
+func TestConcurrentRequestsKeepSeatsConsistent(t *testing.T) {
+	ledger := &server.MemoryLedger{}
+	opts, departures := lockingOptions(2, 100*time.Microsecond)
+	handler, _ := startServer(t, opts, server.WithLedger(ledger))
+	ctx := context.Background()
+
+	// More travellers than seats, each holding at most one booking and
+	// changing it at random
+	const travellers = 48
+	const steps = 30
+	var wg sync.WaitGroup
+	errs := make(chan error, travellers)
+	bookings := make([]string, travellers)
+	for i := 0; i < travellers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			random := rand.New(rand.NewSource(int64(i)))
+			user := &v1.User{FirstName: fmt.Sprintf("Traveller%d", i), LastName: "Lee", Email: fmt.Sprintf("traveller%d@example.com", i)}
+			var bookingID, departureID string
+
+			for step := 0; step < steps; step++ {
+				var err error
+				switch op := random.Intn(5); {
+				case bookingID == "":
+					departureID = departures[random.Intn(len(departures))]
+					var res *connect.Response[v1.PurchaseTicketResponse]
+					res, err = handler.PurchaseTicket(ctx, connect.NewRequest(&v1.PurchaseTicketRequest{
+						Ticket: &v1.Ticket{User: user, DepartureId: departureID},
+					}))
+					if err == nil {
+						bookingID = res.Msg.GetReceipt().GetBookingId()
+					}
+				case op == 0:
+					_, err = handler.CancelBooking(ctx, connect.NewRequest(&v1.CancelBookingRequest{BookingId: bookingID}))
+					if err == nil {
+						bookingID = ""
+					}
+				case op == 1:
+					target := departures[0]
+					if target == departureID {
+						target = departures[1]
+					}
+					var res *connect.Response[v1.ExchangeTicketResponse]
+					res, err = handler.ExchangeTicket(ctx, connect.NewRequest(&v1.ExchangeTicketRequest{BookingId: bookingID, DepartureId: target}))
+					if err == nil {
+						bookingID, departureID = res.Msg.GetReceipt().GetBookingId(), target
+					}
+				case op == 2:
+					_, err = handler.ModifySeat(ctx, connect.NewRequest(&v1.ModifySeatRequest{
+						User:          user,
+						NewSeatNumber: int32(1 + random.Intn(2*server.SEATS_PER_SECTION)),
+					}))
+				case op == 3:
+					_, err = handler.ViewReceipt(ctx, connect.NewRequest(&v1.ViewReceiptRequest{Ticket: &v1.Ticket{User: user}}))
+				default:
+					_, err = handler.ViewAdminDetails(ctx, connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+				}
+
+				// Full trains and taken seats are expected; anything else isn't
+				switch connect.CodeOf(err) {
+				case connect.CodeResourceExhausted, connect.CodeFailedPrecondition:
+				default:
+					if err != nil {
+						errs <- fmt.Errorf("traveller %d: %w", i, err)
+						return
+					}
+				}
+			}
+			bookings[i] = bookingID
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	// Every traveller still holding a booking has a seat of their own
+	held := 0
+	for _, id := range bookings {
+		if id != "" {
+			held++
+		}
+	}
+	admin, err := handler.ViewAdminDetails(ctx, connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+	if err != nil {
+		t.Fatalf("ViewAdminDetails failed: %v", err)
+	}
+	seats := admin.Msg.GetAdminView().GetSeats()
+	if len(seats) != held {
+		t.Fatalf("expected %d seated travellers, got %d", held, len(seats))
+	}
+	seen := make(map[string]bool)
+	for _, seat := range seats {
+		if seen[seat.GetUser().GetEmail()] {
+			t.Fatalf("%s has two seats", seat.GetUser().GetEmail())
+		}
+		seen[seat.GetUser().GetEmail()] = true
+	}
+
+	// The ledger replays cleanly, so no two bookings ever shared a seat
+	replayed, _ := startServer(t, opts, server.WithLedger(ledger))
+	again, err := replayed.ViewAdminDetails(ctx, connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+	if err != nil {
+		t.Fatalf("ViewAdminDetails failed: %v", err)
+	}
+	assertSameSeats(t, seats, again.Msg.GetAdminView().GetSeats())
+}
+
+// BenchmarkPurchaseTicket buys and cancels tickets from many goroutines at
+// once, with each payment call taking a millisecond. Trains that aren't shared
+// are served in parallel, so throughput grows with the number of departures.
+func BenchmarkPurchaseTicket(b *testing.B) {
+	for _, n := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("departures=%d", n), func(b *testing.B) {
+			opts, departures := lockingOptions(n, time.Millisecond)
+			handler, _ := startServer(b, opts)
+			ctx := context.Background()
+			var next atomic.Int64
+
+			b.SetParallelism(16)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					i := next.Add(1)
+					res, err := handler.PurchaseTicket(ctx, connect.NewRequest(&v1.PurchaseTicketRequest{
+						Ticket: &v1.Ticket{
+							User:        &v1.User{FirstName: fmt.Sprintf("Buyer%d", i), LastName: "Lee", Email: fmt.Sprintf("buyer%d@example.com", i)},
+							DepartureId: departures[int(i)%len(departures)],
+						},
+					}))
+					if connect.CodeOf(err) == connect.CodeResourceExhausted {
+						continue
+					}
+					if err != nil {
+						b.Errorf("PurchaseTicket failed: %v", err)
+						return
+					}
+					if _, err := handler.CancelBooking(ctx, connect.NewRequest(&v1.CancelBookingRequest{BookingId: res.Msg.GetReceipt().GetBookingId()})); err != nil {
+						b.Errorf("CancelBooking failed: %v", err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package ticketing_test
+
+import (
+	"os"
+	"testing"
+)
+
+// afterTests are called once every test has run, to stop the servers tests
+// share.
+var afterTests []func()
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	for _, fn := range afterTests {
+		fn()
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,257 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+)
+
+// MANIFEST_CHUNK_SIZE is the most manifest data sent in one message.
+const MANIFEST_CHUNK_SIZE = 32 * 1024
+
+// DEFAULT_MANIFEST_LINES_PER_PAGE is the page length of text manifests when the
+// request doesn't set one.
+const DEFAULT_MANIFEST_LINES_PER_PAGE = 60
+
+// MIN_MANIFEST_LINES_PER_PAGE leaves room for the page and section headings
+// and at least a few passengers on every page.
+const MIN_MANIFEST_LINES_PER_PAGE = 12
+
+// manifestEntry is a passenger on a manifest.
+type manifestEntry struct {
+	Section   string `json:"section"`
+	Seat      int32  `json:"seat"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	BookingID string `json:"booking_id"`
+}
+
+// ExportManifest implements the ExportManifest method of TrainTicketingServiceHandler.
+// The passengers are copied while holding the lock, and then written out and
+// streamed in chunks without it. Only admins may export manifests.
+func (h *MyTrainTicketingServiceHandler) ExportManifest(ctx context.Context, req *connect.Request[v1.ExportManifestRequest], stream *connect.ServerStream[v1.ExportManifestResponse]) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	linesPerPage := int(req.Msg.GetLinesPerPage())
+	if linesPerPage == 0 {
+		linesPerPage = DEFAULT_MANIFEST_LINES_PER_PAGE
+	}
+	if linesPerPage < MIN_MANIFEST_LINES_PER_PAGE {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("pages must have at least %d lines", MIN_MANIFEST_LINES_PER_PAGE))
+	}
+	d, err := h.lookupDeparture(req.Msg.GetDepartureId())
+	if err != nil {
+		return err
+	}
+
+	// Streams aren't seen by the cluster's interceptor, so pass the request
+	// on to the departure's owner here, unless a member already did
+	if !h.owns(d.info.GetId()) && req.Header().Get(FORWARDED_BY_HEADER) == "" {
+		return h.cluster.forwardManifest(ctx, d.info.GetId(), req, stream)
+	}
+
+	// Take a read lock so other reads can go ahead at the same time
+	if err := h.readLock(ctx); err != nil {
+		return err
+	}
+	var entries []manifestEntry
+	for _, b := range h.bookings {
+		// Seats still on hold for a purchase in progress aren't listed
+		if b.status != v1.BookingStatus_BOOKING_STATUS_CONFIRMED || b.ticket.GetDepartureId() != d.info.GetId() {
+			continue
+		}
+		user := b.seat.GetUser()
+		entries = append(entries, manifestEntry{
+			Section:   sectionName(sectionOf(b.seat.GetSeatNumber())),
+			Seat:      b.seat.GetSeatNumber(),
+			FirstName: user.GetFirstName(),
+			LastName:  user.GetLastName(),
+			Email:     user.GetEmail(),
+			BookingID: b.id,
+		})
+	}
+	h.mu.RUnlock()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Seat < entries[j].Seat })
+
+	w := &manifestWriter{stream: stream}
+	switch req.Msg.GetFormat() {
+	case v1.ManifestFormat_MANIFEST_FORMAT_UNSPECIFIED, v1.ManifestFormat_MANIFEST_FORMAT_CSV:
+		err = writeManifestCSV(w, entries)
+	case v1.ManifestFormat_MANIFEST_FORMAT_JSONL:
+		err = writeManifestJSONL(w, entries)
+	case v1.ManifestFormat_MANIFEST_FORMAT_TEXT:
+		err = writeManifestText(w, d.info, entries, linesPerPage)
+	default:
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown manifest format %v", req.Msg.GetFormat()))
+	}
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// forwardManifest streams the manifest of a departure from its owner.
+func (c *cluster) forwardManifest(ctx context.Context, departureID string, req *connect.Request[v1.ExportManifestRequest], stream *connect.ServerStream[v1.ExportManifestResponse]) error {
+	client := ticketingv1.NewTrainTicketingServiceClient(c.http, c.ring.Owner(departureID))
+	forwarded := connect.NewRequest(req.Msg)
+	for _, name := range forwardedHeaders {
+		if value := req.Header().Get(name); value != "" {
+			forwarded.Header().Set(name, value)
+		}
+	}
+	c.markForwarded(forwarded.Header())
+
+	res, err := client.ExportManifest(ctx, forwarded)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for res.Receive() {
+		if err := stream.Send(res.Msg()); err != nil {
+			return err
+		}
+	}
+	return res.Err()
+}
+
+// manifestWriter sends what is written to it in chunks of up to
+// MANIFEST_CHUNK_SIZE.
+type manifestWriter struct {
+	stream *connect.ServerStream[v1.ExportManifestResponse]
+	buf    []byte
+}
+
+func (w *manifestWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= MANIFEST_CHUNK_SIZE {
+		if err := w.stream.Send(&v1.ExportManifestResponse{Data: w.buf[:MANIFEST_CHUNK_SIZE]}); err != nil {
+			return 0, err
+		}
+		w.buf = append([]byte(nil), w.buf[MANIFEST_CHUNK_SIZE:]...)
+	}
+	return len(p), nil
+}
+
+// Flush sends whatever is left.
+func (w *manifestWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.stream.Send(&v1.ExportManifestResponse{Data: w.buf})
+	w.buf = nil
+	return err
+}
+
+func writeManifestCSV(w io.Writer, entries []manifestEntry) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"section", "seat", "first_name", "last_name", "email", "booking_id"})
+	for _, e := range entries {
+		cw.Write([]string{e.Section, strconv.Itoa(int(e.Seat)), e.FirstName, e.LastName, e.Email, e.BookingID})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeManifestJSONL(w io.Writer, entries []manifestEntry) error {
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeManifestText lays the manifest out in pages of linesPerPage lines,
+// separated by form feeds. Each page starts with the train and page number,
+// and each section with a heading that is repeated when it runs onto the next
+// page.
+func writeManifestText(w io.Writer, info *v1.Departure, entries []manifestEntry, linesPerPage int) error {
+	const columns = "  Seat  Name                            Email                           Booking"
+
+	// Lay out the body of every page first, so the page count is known
+	var pages [][]string
+	var page []string
+	newPage := func() {
+		if page != nil {
+			pages = append(pages, page)
+		}
+		page = []string{}
+	}
+	room := func() int { return linesPerPage - 3 - len(page) } // The page heading takes three lines
+	newPage()
+	for _, section := range []string{"A", "B"} {
+		var seats []manifestEntry
+		for _, e := range entries {
+			if e.Section == section {
+				seats = append(seats, e)
+			}
+		}
+
+		// Keep a section heading together with at least one passenger
+		if len(page) > 0 && room() < 4 {
+			newPage()
+		}
+		if len(page) > 0 {
+			page = append(page, "")
+		}
+		page = append(page, fmt.Sprintf("Section %s (%d passengers)", section, len(seats)), columns)
+		if len(seats) == 0 {
+			page = append(page, "  No passengers")
+		}
+		for _, e := range seats {
+			if room() == 0 {
+				newPage()
+				page = append(page, fmt.Sprintf("Section %s (continued)", section), columns)
+			}
+			page = append(page, fmt.Sprintf("  %4d  %-30.30s  %-30.30s  %s", e.Seat, e.LastName+", "+e.FirstName, e.Email, e.BookingID))
+		}
+	}
+	pages = append(pages, page)
+
+	departs := info.GetDepartureTime().AsTime().UTC().Format("2006-01-02 15:04 MST")
+	for i, body := range pages {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\f"); err != nil {
+				return err
+			}
+		}
+		title := fmt.Sprintf("PASSENGER MANIFEST  %s  %s -> %s", info.GetId(), info.GetFrom(), info.GetTo())
+		pageNumber := fmt.Sprintf("Page %d of %d", i+1, len(pages))
+		lines := append([]string{
+			title,
+			fmt.Sprintf("Departs %s%s", departs, leftPad(pageNumber, len(columns)-len("Departs ")-len(departs))),
+			strings.Repeat("=", len(columns)),
+		}, body...)
+		if _, err := io.WriteString(w, strings.Join(lines, "\n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func leftPad(s string, width int) string {
+	if len(s) >= width {
+		return " " + s
+	}
+	return strings.Repeat(" ", width-len(s)) + s
+}
+
+// sectionName returns the letter printed for a section.
+func sectionName(section v1.Section_SectionType) string {
+	if section == v1.Section_SECTION_TYPE_B {
+		return "B"
+	}
+	return "A"
+}
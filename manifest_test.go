@@ -0,0 +1,147 @@
+package ticketing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// exportManifest returns the whole manifest streamed for req.
+func exportManifest(t *testing.T, client ticketingv1.TrainTicketingServiceClient, req *v1.ExportManifestRequest) string {
+	t.Helper()
+	res, err := client.ExportManifest(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("ExportManifest failed: %v", err)
+	}
+	defer res.Close()
+	var buf bytes.Buffer
+	for res.Receive() {
+		buf.Write(res.Msg().GetData())
+	}
+	if err := res.Err(); err != nil {
+		t.Fatalf("ExportManifest failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestExportManifest(t *testing.T) {
+	client := newTestClient(t)
+	for i := 0; i < 14; i++ {
+		purchaseTicket(t, client, fmt.Sprintf("Passenger%d", i), "Lee", fmt.Sprintf("p%d@example.com", i))
+	}
+	johnID := purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "Passenger0"},
+		NewSeatNumber: 20,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+
+	// CSV lists every passenger in seat order
+	records, err := csv.NewReader(strings.NewReader(exportManifest(t, client, &v1.ExportManifestRequest{}))).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 16 || strings.Join(records[0], ",") != "section,seat,first_name,last_name,email,booking_id" {
+		t.Fatalf("expected a header and 15 passengers, got %v", records)
+	}
+	if got := strings.Join(records[1], ","); got != "A,2,Passenger1,Lee,p1@example.com,"+records[1][5] {
+		t.Fatalf("unexpected first passenger %q", got)
+	}
+	if last := records[15]; last[0] != "B" || last[1] != "20" || last[2] != "Passenger0" {
+		t.Fatalf("expected Passenger0 last, in seat 20, got %v", last)
+	}
+
+	// JSON Lines has one object per passenger
+	lines := strings.Split(strings.TrimSpace(exportManifest(t, client, &v1.ExportManifestRequest{Format: v1.ManifestFormat_MANIFEST_FORMAT_JSONL})), "\n")
+	if len(lines) != 15 {
+		t.Fatalf("expected 15 lines, got %d", len(lines))
+	}
+	var john struct {
+		Section   string `json:"section"`
+		Seat      int32  `json:"seat"`
+		BookingID string `json:"booking_id"`
+	}
+	if err := json.Unmarshal([]byte(lines[13]), &john); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if john.Section != "B" || john.Seat != 15 || john.BookingID != johnID {
+		t.Fatalf("expected John in seat 15, got %+v", john)
+	}
+
+	// Text is split into pages of the requested length, and both sections run
+	// onto the next page
+	text := exportManifest(t, client, &v1.ExportManifestRequest{Format: v1.ManifestFormat_MANIFEST_FORMAT_TEXT, LinesPerPage: 12})
+	pages := strings.Split(text, "\f")
+	if len(pages) != 3 {
+		t.Fatalf("expected 3 pages, got %d:\n%s", len(pages), text)
+	}
+	for i, page := range pages {
+		lines := strings.Split(strings.TrimSuffix(page, "\n"), "\n")
+		if len(lines) > 12 {
+			t.Fatalf("page %d has %d lines", i+1, len(lines))
+		}
+		if !strings.Contains(lines[1], fmt.Sprintf("Page %d of 3", i+1)) {
+			t.Fatalf("page %d is missing its number: %q", i+1, lines[1])
+		}
+	}
+	if !strings.Contains(pages[2], "Section B (continued)") {
+		t.Fatalf("expected section B to continue on the last page:\n%s", pages[2])
+	}
+
+	if err := exportManifestErr(client, &v1.ExportManifestRequest{DepartureId: "nowhere"}); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound for an unknown departure, got %v", err)
+	}
+	if err := exportManifestErr(client, &v1.ExportManifestRequest{LinesPerPage: 5}); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for short pages, got %v", err)
+	}
+}
+
+// exportManifestErr returns the error a stream for req ends with.
+func exportManifestErr(client ticketingv1.TrainTicketingServiceClient, req *v1.ExportManifestRequest) error {
+	res, err := client.ExportManifest(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for res.Receive() {
+	}
+	return res.Err()
+}
+
+func TestExportManifestNeedsAdmin(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)))
+	agent := newClient(srv, signJWT(t, "agent-7", "agent"))
+	purchaseTicket(t, agent, "Jane", "Roe", "jane@example.com")
+
+	if err := exportManifestErr(agent, &v1.ExportManifestRequest{}); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied exporting without the admin role, got %v", err)
+	}
+	if manifest := exportManifest(t, newClient(srv, signJWT(t, "alice", "admin")), &v1.ExportManifestRequest{}); !strings.Contains(manifest, "jane@example.com") {
+		t.Fatalf("expected an admin to export the manifest, got %q", manifest)
+	}
+}
+
+func TestExportManifestFromAnyClusterMember(t *testing.T) {
+	departure := &v1.Departure{Id: "morning", From: "London", To: "Paris", DepartureTime: timestamppb.New(time.Now().Add(48 * time.Hour)), Fare: 20}
+	members := newCluster(t, 2, departure)
+	purchaseTicket(t, members[0].client, "Jane", "Roe", "jane@example.com")
+
+	want := exportManifest(t, members[0].client, &v1.ExportManifestRequest{})
+	if got := exportManifest(t, members[1].client, &v1.ExportManifestRequest{}); got != want || !strings.Contains(got, "jane@example.com") {
+		t.Fatalf("expected both members to export\n%s\ngot\n%s", want, got)
+	}
+}
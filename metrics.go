@@ -0,0 +1,200 @@
+package ticketing
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// metrics are the Prometheus metrics a handler keeps once WithMetrics is used.
+// Sales are counted by the handler that records them, so handlers sharing a
+// ledger don't count each other's; add them up across handlers instead.
+type metrics struct {
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	redemptions *prometheus.CounterVec
+	revenue     *prometheus.CounterVec
+	refunds     *prometheus.CounterVec
+}
+
+// WithMetrics registers the handler's metrics with registerer:
+//
+//   - ticketing_rpc_requests_total and ticketing_rpc_duration_seconds, by
+//     procedure and code
+//   - ticketing_seats, the seats that are free, held while being paid for and
+//     sold, by departure and section
+//   - ticketing_discount_redemptions_total, by discount code
+//   - ticketing_revenue_total and ticketing_refunds_total, by departure. An
+//     exchange refunds the old ticket and sells the new one, and imported
+//     bookings count as sold at the price they were imported at.
+//
+// Serve them with promhttp.HandlerFor.
+func WithMetrics(registerer prometheus.Registerer) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		m := &metrics{
+			requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "ticketing_rpc_requests_total",
+				Help: "RPCs handled, by procedure and code.",
+			}, []string{"procedure", "code"}),
+			duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "ticketing_rpc_duration_seconds",
+				Help:    "Time taken to handle RPCs, by procedure and code.",
+				Buckets: prometheus.DefBuckets,
+			}, []string{"procedure", "code"}),
+			redemptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "ticketing_discount_redemptions_total",
+				Help: "Discount codes used by purchases, by code.",
+			}, []string{"code"}),
+			revenue: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "ticketing_revenue_total",
+				Help: "Price paid for the tickets sold, by departure.",
+			}, []string{"departure"}),
+			refunds: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "ticketing_refunds_total",
+				Help: "Money handed back for cancelled and exchanged tickets, by departure.",
+			}, []string{"departure"}),
+		}
+		registerer.MustRegister(m.requests, m.duration, m.redemptions, m.revenue, m.refunds, &seatCollector{h: h})
+		h.metrics = m
+	}
+}
+
+// WrapUnary implements connect.Interceptor by timing and counting RPCs.
+func (m *metrics) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		m.observe(req.Spec().Procedure, start, err)
+		return res, err
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor. The handler makes no
+// streaming calls, so there's nothing to count.
+func (m *metrics) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor by timing and counting
+// streaming RPCs, from when they start until the handler returns.
+func (m *metrics) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		err := next(ctx, conn)
+		m.observe(conn.Spec().Procedure, start, err)
+		return err
+	}
+}
+
+func (m *metrics) observe(procedure string, start time.Time, err error) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	m.requests.WithLabelValues(procedure, code).Inc()
+	m.duration.WithLabelValues(procedure, code).Observe(time.Since(start).Seconds())
+}
+
+// countSales updates the sales metrics for an event the handler has just
+// recorded and applied. The caller must hold h.mu.
+func (h *MyTrainTicketingServiceHandler) countSales(event *v1.LedgerEvent) {
+	if h.metrics == nil {
+		return
+	}
+	switch e := event.GetEvent().(type) {
+	case *v1.LedgerEvent_BookingConfirmed:
+		ticket := h.bookings[e.BookingConfirmed.GetBookingId()].ticket
+		h.metrics.revenue.WithLabelValues(ticket.GetDepartureId()).Add(float64(ticket.GetPricePaid()))
+
+	case *v1.LedgerEvent_DiscountRedeemed:
+		h.metrics.redemptions.WithLabelValues(e.DiscountRedeemed.GetDiscountCode()).Inc()
+
+	case *v1.LedgerEvent_BookingCancelled:
+		ticket := h.bookings[e.BookingCancelled.GetBookingId()].ticket
+		h.metrics.refunds.WithLabelValues(ticket.GetDepartureId()).Add(float64(e.BookingCancelled.GetRefundAmount()))
+
+	case *v1.LedgerEvent_BookingExchanged:
+		old := h.bookings[e.BookingExchanged.GetBookingId()].ticket
+		ticket := e.BookingExchanged.GetTicket()
+		h.metrics.refunds.WithLabelValues(old.GetDepartureId()).Add(float64(old.GetPricePaid()))
+		h.metrics.revenue.WithLabelValues(ticket.GetDepartureId()).Add(float64(ticket.GetPricePaid()))
+
+	case *v1.LedgerEvent_BookingsImported:
+		// Imported tickets were sold elsewhere without discount codes, but
+		// what was paid for them is still revenue
+		for _, imported := range e.BookingsImported.GetBookings() {
+			ticket := imported.GetTicket()
+			h.metrics.revenue.WithLabelValues(ticket.GetDepartureId()).Add(float64(ticket.GetPricePaid()))
+		}
+	}
+}
+
+// seatCollector reports the seats of every departure the handler serves when
+// metrics are gathered, so they are always in step with the bookings.
+type seatCollector struct {
+	h *MyTrainTicketingServiceHandler
+}
+
+var seatsDesc = prometheus.NewDesc("ticketing_seats",
+	"Seats by departure, section and state: free, held while being paid for, held for review, or sold.",
+	[]string{"departure", "section", "state"}, nil)
+
+// Describe implements prometheus.Collector.
+func (c *seatCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- seatsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *seatCollector) Collect(ch chan<- prometheus.Metric) {
+	h := c.h
+	if err := h.readLock(context.Background()); err != nil {
+		ch <- prometheus.NewInvalidMetric(seatsDesc, err)
+		return
+	}
+	defer h.mu.RUnlock()
+
+	type key struct {
+		departure string
+		section   v1.Section_SectionType
+		state     string
+	}
+	counts := make(map[key]int)
+	for _, info := range h.schedule {
+		if !h.owns(info.GetId()) {
+			continue
+		}
+		for _, seat := range h.departures[info.GetId()].seats {
+			// Every state is reported, even when no seat is in it
+			section := sectionOf(seat.GetSeatNumber())
+			for _, state := range []string{"free", "held", "review", "sold"} {
+				counts[key{info.GetId(), section, state}] += 0
+			}
+			if seat.GetUser() == nil {
+				counts[key{info.GetId(), section, "free"}]++
+			}
+		}
+	}
+	for _, b := range h.bookings {
+		state := ""
+		switch b.status {
+		case v1.BookingStatus_BOOKING_STATUS_HELD:
+			state = "held"
+		case v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW:
+			state = "review"
+		case v1.BookingStatus_BOOKING_STATUS_CONFIRMED:
+			state = "sold"
+		default:
+			continue
+		}
+		counts[key{b.ticket.GetDepartureId(), sectionOf(b.seat.GetSeatNumber()), state}]++
+	}
+	for k, n := range counts {
+		section := strings.TrimPrefix(k.section.String(), "SECTION_TYPE_")
+		ch <- prometheus.MustNewConstMetric(seatsDesc, prometheus.GaugeValue, float64(n), k.departure, section, k.state)
+	}
+}
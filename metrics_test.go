@@ -0,0 +1,161 @@
+package ticketing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// metricValue returns the value of the metric with the given name and labels,
+// or the number of observations for a histogram. It is 0 when there is no
+// such metric.
+func metricValue(t *testing.T, registry *prometheus.Registry, name string, labels ...string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for i := 0; i < len(labels); i += 2 {
+				found := false
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == labels[i] && label.GetValue() == labels[i+1] {
+						found = true
+					}
+				}
+				if !found {
+					continue metrics
+				}
+			}
+			switch {
+			case metric.GetCounter() != nil:
+				return metric.GetCounter().GetValue()
+			case metric.GetGauge() != nil:
+				return metric.GetGauge().GetValue()
+			case metric.GetHistogram() != nil:
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := newTestClient(t, server.WithMetrics(registry))
+
+	jane := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	purchaseTicket(t, client, "John", "Doe", "john@example.com", withDiscount("WOW1"))
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "John"},
+		NewSeatNumber: 15,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	cancelled, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: jane}))
+	if err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if _, err := client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{DepartureId: "nowhere"})); err == nil {
+		t.Fatal("expected ViewSeatMap to fail for an unknown departure")
+	}
+	exportManifest(t, client, &v1.ExportManifestRequest{})
+
+	for _, tc := range []struct {
+		name   string
+		labels []string
+		want   float64
+	}{
+		{"ticketing_rpc_requests_total", []string{"procedure", ticketingv1.TrainTicketingServicePurchaseTicketProcedure, "code", "ok"}, 2},
+		{"ticketing_rpc_duration_seconds", []string{"procedure", ticketingv1.TrainTicketingServicePurchaseTicketProcedure, "code", "ok"}, 2},
+		{"ticketing_rpc_requests_total", []string{"procedure", ticketingv1.TrainTicketingServiceViewSeatMapProcedure, "code", "not_found"}, 1},
+		{"ticketing_rpc_requests_total", []string{"procedure", ticketingv1.TrainTicketingServiceExportManifestProcedure, "code", "ok"}, 1},
+		{"ticketing_discount_redemptions_total", []string{"code", "WOW1"}, 1},
+		{"ticketing_revenue_total", []string{"departure", server.DEFAULT_DEPARTURE_ID}, 20 + 18},
+		{"ticketing_refunds_total", []string{"departure", server.DEFAULT_DEPARTURE_ID}, float64(cancelled.Msg.GetCancellationReceipt().GetRefundAmount())},
+		{"ticketing_seats", []string{"departure", server.DEFAULT_DEPARTURE_ID, "section", "A", "state", "free"}, 10},
+		{"ticketing_seats", []string{"departure", server.DEFAULT_DEPARTURE_ID, "section", "A", "state", "sold"}, 0},
+		{"ticketing_seats", []string{"departure", server.DEFAULT_DEPARTURE_ID, "section", "B", "state", "free"}, 9},
+		{"ticketing_seats", []string{"departure", server.DEFAULT_DEPARTURE_ID, "section", "B", "state", "sold"}, 1},
+		{"ticketing_seats", []string{"departure", server.DEFAULT_DEPARTURE_ID, "section", "B", "state", "held"}, 0},
+	} {
+		if got := metricValue(t, registry, tc.name, tc.labels...); got != tc.want {
+			t.Errorf("expected %s%v to be %v, got %v", tc.name, tc.labels, tc.want, got)
+		}
+	}
+}
+
+func TestMetricsCountExchanges(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := newTestClient(t, server.WithMetrics(registry), testDepartures(time.Now().Add(30*24*time.Hour)))
+
+	bookingID := purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	if _, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   bookingID,
+		DepartureId: "evening",
+	})); err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+
+	// The morning ticket was refunded and an evening one sold
+	for _, tc := range []struct {
+		name      string
+		departure string
+		want      float64
+	}{
+		{"ticketing_revenue_total", "morning", 20},
+		{"ticketing_refunds_total", "morning", 20},
+		{"ticketing_revenue_total", "evening", 30},
+		{"ticketing_refunds_total", "evening", 0},
+	} {
+		if got := metricValue(t, registry, tc.name, "departure", tc.departure); got != tc.want {
+			t.Errorf("expected %s for %s to be %v, got %v", tc.name, tc.departure, tc.want, got)
+		}
+	}
+	if got := metricValue(t, registry, "ticketing_seats", "departure", "evening", "section", "A", "state", "sold"); got != 1 {
+		t.Errorf("expected 1 seat sold on the evening train, got %v", got)
+	}
+}
+
+func TestMetricsCountImports(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := newTestClient(t, testDepartures(time.Now().Add(48*time.Hour)), server.WithMetrics(registry))
+
+	discounted := importRow("Cat", "evening", v1.Section_SECTION_TYPE_UNSPECIFIED, 0)
+	price := float32(5)
+	discounted.PriceOverride = &price
+	importBookings(t, client, false, []*v1.ImportBookingRow{importRow("Ann", "morning", v1.Section_SECTION_TYPE_B, 0), discounted})
+
+	// A dry run sells nothing
+	importBookings(t, client, true, []*v1.ImportBookingRow{importRow("Bob", "morning", v1.Section_SECTION_TYPE_B, 0)})
+
+	for _, tc := range []struct {
+		name   string
+		labels []string
+		want   float64
+	}{
+		{"ticketing_revenue_total", []string{"departure", "morning"}, 20},
+		{"ticketing_revenue_total", []string{"departure", "evening"}, 5},
+		{"ticketing_refunds_total", []string{"departure", "morning"}, 0},
+		{"ticketing_discount_redemptions_total", nil, 0},
+		{"ticketing_seats", []string{"departure", "morning", "section", "B", "state", "sold"}, 1},
+		{"ticketing_seats", []string{"departure", "evening", "section", "A", "state", "sold"}, 1},
+	} {
+		if got := metricValue(t, registry, tc.name, tc.labels...); got != tc.want {
+			t.Errorf("expected %s%v to be %v, got %v", tc.name, tc.labels, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+-- Booking events, in the order they were recorded. The sequence number is
+-- chosen by the handler, so two handlers recording at once collide on the
+-- primary key and the later one has to catch up first.
+CREATE TABLE ledger_events (
+    sequence    BIGINT PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    event       JSONB NOT NULL
+);
+
+-- Snapshots of the handler's state, so startup only replays newer events.
+CREATE TABLE ledger_snapshots (
+    sequence BIGINT PRIMARY KEY,
+    taken_at TIMESTAMPTZ NOT NULL,
+    snapshot BYTEA NOT NULL
+);
+
+-- Every seat on every departure and the booking holding it. Handlers claim a
+-- free seat for a short while before recording a booking for it, so
+-- concurrent purchases are given different seats.
+CREATE TABLE seats (
+    departure_id  TEXT NOT NULL,
+    seat_number   INTEGER NOT NULL,
+    section       INTEGER NOT NULL,
+    booking_id    TEXT UNIQUE,
+    claimed_by    TEXT,
+    claimed_until TIMESTAMPTZ,
+    PRIMARY KEY (departure_id, seat_number)
+);
+
+CREATE INDEX seats_free ON seats (departure_id, seat_number) WHERE booking_id IS NULL;
+CREATE INDEX seats_claimed_by ON seats (claimed_by) WHERE claimed_by IS NOT NULL;
@@ -0,0 +1,251 @@
+package ticketing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OPENAPI_PATH is where the OpenAPI document describing the REST routes is
+// served.
+const OPENAPI_PATH = "/openapi.json"
+
+// ERROR_SCHEMA names the schema of the JSON errors REST calls fail with.
+const ERROR_SCHEMA = "connect.Error"
+
+// openAPIDocument is the OpenAPI 3 document of the REST routes, built from the
+// same google.api.http options the gateway serves.
+var openAPIDocument = sync.OnceValue(func() []byte {
+	schemas := map[string]any{ERROR_SCHEMA: errorSchema()}
+	paths := map[string]map[string]any{}
+	bindings := map[protoreflect.Name]int{}
+	for _, route := range restRoutes() {
+		// Additional bindings get operation IDs of their own, numbered from 2
+		operationID := string(route.method.Name())
+		if bindings[route.method.Name()]++; bindings[route.method.Name()] > 1 {
+			operationID += fmt.Sprint(bindings[route.method.Name()])
+		}
+		operation := map[string]any{
+			"operationId": operationID,
+			"tags":        []string{string(route.method.Parent().Name())},
+			"parameters":  routeParameters(route, schemas),
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     jsonContent(messageSchema(route.method.Output(), schemas)),
+				},
+				"default": map[string]any{
+					"description": "The call failed",
+					"content":     jsonContent(map[string]any{"$ref": "#/components/schemas/" + ERROR_SCHEMA}),
+				},
+			},
+		}
+		if route.body != "" {
+			body := route.method.Input()
+			if route.body != "*" {
+				body = body.Fields().ByName(protoreflect.Name(route.body)).Message()
+			}
+			operation["requestBody"] = map[string]any{"required": true, "content": jsonContent(messageSchema(body, schemas))}
+		}
+
+		path := "/" + strings.Join(route.segments, "/")
+		if route.customVerb != "" {
+			path += ":" + route.customVerb
+		}
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(route.verb)] = operation
+	}
+
+	document, _ := json.MarshalIndent(map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": "Train ticketing", "version": "v1"},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas":         schemas,
+			"securitySchemes": map[string]any{"bearer": map[string]any{"type": "http", "scheme": "bearer"}},
+		},
+		"security": []any{map[string]any{"bearer": []string{}}},
+	}, "", "  ")
+	return document
+})
+
+// serveOpenAPI serves the OpenAPI document of the REST routes.
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIDocument())
+}
+
+// routeParameters lists the path variables of a route and, unless the body
+// holds the whole request, the fields that can be given in the query string.
+func routeParameters(route restRoute, schemas map[string]any) []any {
+	parameters := []any{}
+	bound := map[string]bool{route.body: true}
+	for _, segment := range route.segments {
+		if name, ok := restVariable(segment); ok {
+			bound[name] = true
+			parameters = append(parameters, map[string]any{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   fieldSchema(fieldAt(route.method.Input(), name), schemas),
+			})
+		}
+	}
+	if route.body != "*" {
+		parameters = append(parameters, queryParameters(route.method.Input(), "", bound, map[protoreflect.FullName]bool{}, schemas)...)
+	}
+	return parameters
+}
+
+// queryParameters lists the fields of a message that can be given in the query
+// string, with the fields of nested messages named by their dotted paths.
+func queryParameters(message protoreflect.MessageDescriptor, prefix string, bound map[string]bool, seen map[protoreflect.FullName]bool, schemas map[string]any) []any {
+	seen[message.FullName()] = true
+	defer delete(seen, message.FullName())
+
+	var parameters []any
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if bound[prefix+string(field.Name())] || field.IsMap() {
+			continue
+		}
+		if field.Message() != nil && !field.IsList() && !isWellKnownType(field.Message()) {
+			if !seen[field.Message().FullName()] {
+				parameters = append(parameters, queryParameters(field.Message(), prefix+string(field.Name())+".", bound, seen, schemas)...)
+			}
+			continue
+		}
+		parameters = append(parameters, map[string]any{
+			"name":   prefix + field.JSONName(),
+			"in":     "query",
+			"schema": fieldSchema(field, schemas),
+		})
+	}
+	return parameters
+}
+
+// fieldAt returns the field at a dotted path of field names.
+func fieldAt(message protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	var field protoreflect.FieldDescriptor
+	for _, name := range strings.Split(path, ".") {
+		field = message.Fields().ByName(protoreflect.Name(name))
+		message = field.Message()
+	}
+	return field
+}
+
+// messageSchema returns a reference to the schema of a message, adding the
+// schemas of it and the messages it holds to schemas.
+func messageSchema(message protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	if schema, ok := wellKnownSchemas[message.FullName()]; ok {
+		return schema
+	}
+	name := string(message.FullName())
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+
+	properties := map[string]any{}
+	schemas[name] = map[string]any{"type": "object", "properties": properties}
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		properties[fields.Get(i).JSONName()] = fieldSchema(fields.Get(i), schemas)
+	}
+	return ref
+}
+
+// fieldSchema returns the schema of a field's value, as protojson writes it.
+func fieldSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch {
+	case field.IsMap():
+		return map[string]any{"type": "object", "additionalProperties": fieldSchema(field.MapValue(), schemas)}
+	case field.IsList():
+		return map[string]any{"type": "array", "items": singularSchema(field, schemas)}
+	}
+	return singularSchema(field, schemas)
+}
+
+func singularSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson writes 64 bit integers as strings
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		name := string(field.Enum().FullName())
+		if _, ok := schemas[name]; !ok {
+			var values []string
+			for i := 0; i < field.Enum().Values().Len(); i++ {
+				values = append(values, string(field.Enum().Values().Get(i).Name()))
+			}
+			schemas[name] = map[string]any{"type": "string", "enum": values}
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return messageSchema(field.Message(), schemas)
+}
+
+// wellKnownSchemas are the schemas of the well known types protojson writes
+// as something other than an object.
+var wellKnownSchemas = map[protoreflect.FullName]map[string]any{
+	"google.protobuf.Timestamp": {"type": "string", "format": "date-time"},
+	"google.protobuf.Duration":  {"type": "string"},
+}
+
+func isWellKnownType(message protoreflect.MessageDescriptor) bool {
+	_, ok := wellKnownSchemas[message.FullName()]
+	return ok
+}
+
+// errorSchema is the schema of Connect's JSON errors.
+func errorSchema() map[string]any {
+	var codes []string
+	for code := connect.CodeCanceled; code <= connect.CodeUnauthenticated; code++ {
+		codes = append(codes, code.String())
+	}
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"code":    map[string]any{"type": "string", "enum": codes},
+			"message": map[string]any{"type": "string"},
+			"details": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"type":  map[string]any{"type": "string"},
+						"value": map[string]any{"type": "string", "format": "byte"},
+						"debug": map[string]any{"type": "object"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
@@ -0,0 +1,266 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
+)
+
+// DEFAULT_PAYMENT_TIMEOUT bounds each call to the payment provider.
+const DEFAULT_PAYMENT_TIMEOUT = 10 * time.Second
+
+// ErrPaymentDeclined is returned by a PaymentProvider when the payment method
+// is refused.
+var ErrPaymentDeclined = errors.New("payment declined")
+
+// PaymentProvider moves money for bookings. PurchaseTicket authorizes and
+// captures the fare while the seat is held, voids the authorization if it
+// can't be captured, and cancellations and exchanges refund captured payments.
+type PaymentProvider interface {
+	// Authorize reserves amount on the payment method and returns an
+	// authorization ID for the other calls.
+	Authorize(ctx context.Context, method *v1.PaymentMethod, amount float32) (string, error)
+	// Capture collects an authorized payment.
+	Capture(ctx context.Context, authorizationID string) error
+	// Void releases an authorization that won't be captured.
+	Void(ctx context.Context, authorizationID string) error
+	// Refund returns part or all of a captured payment.
+	Refund(ctx context.Context, authorizationID string, amount float32) error
+}
+
+// WithPaymentProvider sets the provider used to charge for tickets. By default
+// a FakePaymentProvider that approves every payment is used.
+func WithPaymentProvider(provider PaymentProvider) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.paymentProvider = provider
+	}
+}
+
+// WithPaymentTimeout overrides DEFAULT_PAYMENT_TIMEOUT.
+func WithPaymentTimeout(timeout time.Duration) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.paymentTimeout = timeout
+	}
+}
+
+// charge authorizes and captures amount. A failed capture voids the
+// authorization so no money is left on hold.
+func (h *MyTrainTicketingServiceHandler) charge(ctx context.Context, method *v1.PaymentMethod, amount float32) (payments []*v1.Payment, err error) {
+	if amount <= 0 {
+		return nil, nil
+	}
+	ctx, span := h.startSpan(ctx, "payment.Charge", attribute.Float64("payment.amount", float64(amount)))
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := context.WithTimeout(ctx, h.paymentTimeout)
+	defer cancel()
+
+	id, err := h.paymentProvider.Authorize(ctx, method, amount)
+	if err != nil {
+		return nil, paymentError(err)
+	}
+	if err := h.paymentProvider.Capture(ctx, id); err != nil {
+		voidCtx, cancelVoid := context.WithTimeout(context.WithoutCancel(ctx), h.paymentTimeout)
+		defer cancelVoid()
+		_ = h.paymentProvider.Void(voidCtx, id)
+		return nil, paymentError(err)
+	}
+	return []*v1.Payment{{Id: id, Amount: amount}}, nil
+}
+
+// refund returns amount from a booking's payments, newest first, and returns
+// whatever is left to refund later. The payments passed in are not modified.
+func (h *MyTrainTicketingServiceHandler) refund(ctx context.Context, payments []*v1.Payment, amount float32) (remaining []*v1.Payment, err error) {
+	ctx, span := h.startSpan(ctx, "payment.Refund", attribute.Float64("payment.amount", float64(amount)))
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := context.WithTimeout(ctx, h.paymentTimeout)
+	defer cancel()
+
+	remaining, parts := splitRefund(payments, amount)
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] <= 0 {
+			continue
+		}
+		if err := h.paymentProvider.Refund(ctx, payments[i].GetId(), parts[i]); err != nil {
+			return nil, paymentError(err)
+		}
+	}
+	return remaining, nil
+}
+
+// splitRefund works out how much of amount refund takes from each payment,
+// newest first, and what is left of the payments afterwards, without paying
+// anything out. Handlers record what is left before refunding, so a booking
+// whose change can't be recorded is never refunded.
+func splitRefund(payments []*v1.Payment, amount float32) (remaining []*v1.Payment, parts []float32) {
+	remaining = make([]*v1.Payment, len(payments))
+	for i, p := range payments {
+		remaining[i] = proto.Clone(p).(*v1.Payment)
+	}
+	parts = make([]float32, len(payments))
+	for i := len(remaining) - 1; i >= 0 && amount > 0; i-- {
+		p := remaining[i]
+		part := min(amount, p.GetAmount())
+		if part <= 0 {
+			continue
+		}
+		parts[i] = part
+		p.Amount -= part
+		amount -= part
+	}
+	return remaining, parts
+}
+
+// paymentError converts a provider error into a connect error.
+func paymentError(err error) error {
+	switch {
+	case errors.Is(err, ErrPaymentDeclined):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("payment provider timed out: %w", err))
+	default:
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("payment failed: %w", err))
+	}
+}
+
+// FakePaymentStatus is the state of a payment made through a FakePaymentProvider.
+type FakePaymentStatus string
+
+const (
+	FakePaymentAuthorized FakePaymentStatus = "authorized"
+	FakePaymentCaptured   FakePaymentStatus = "captured"
+	FakePaymentVoided     FakePaymentStatus = "voided"
+)
+
+// FakePayment records a payment made through a FakePaymentProvider.
+type FakePayment struct {
+	ID       string
+	Token    string
+	Amount   float32
+	Refunded float32
+	Status   FakePaymentStatus
+}
+
+// FakePaymentProvider is a deterministic in-process PaymentProvider for tests
+// and demos. It approves every payment except those made with a token listed
+// in DeclineTokens, and waits Latency before answering each call so callers'
+// timeouts can be exercised. Fields must be set before the provider is used.
+type FakePaymentProvider struct {
+	DeclineTokens []string
+	Latency       time.Duration
+
+	mu       sync.Mutex
+	payments []*FakePayment
+}
+
+// Payments returns a copy of every payment the provider has authorized, in the
+// order they were made.
+func (f *FakePaymentProvider) Payments() []FakePayment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payments := make([]FakePayment, len(f.payments))
+	for i, p := range f.payments {
+		payments[i] = *p
+	}
+	return payments
+}
+
+// Authorize implements PaymentProvider.
+func (f *FakePaymentProvider) Authorize(ctx context.Context, method *v1.PaymentMethod, amount float32) (string, error) {
+	if err := f.wait(ctx); err != nil {
+		return "", err
+	}
+	for _, token := range f.DeclineTokens {
+		if token == method.GetToken() {
+			return "", ErrPaymentDeclined
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p := &FakePayment{
+		ID:     fmt.Sprintf("pay_%d", len(f.payments)+1),
+		Token:  method.GetToken(),
+		Amount: amount,
+		Status: FakePaymentAuthorized,
+	}
+	f.payments = append(f.payments, p)
+	return p.ID, nil
+}
+
+// Capture implements PaymentProvider.
+func (f *FakePaymentProvider) Capture(ctx context.Context, authorizationID string) error {
+	return f.update(ctx, authorizationID, func(p *FakePayment) error {
+		if p.Status != FakePaymentAuthorized {
+			return fmt.Errorf("cannot capture %s payment", p.Status)
+		}
+		p.Status = FakePaymentCaptured
+		return nil
+	})
+}
+
+// Void implements PaymentProvider.
+func (f *FakePaymentProvider) Void(ctx context.Context, authorizationID string) error {
+	return f.update(ctx, authorizationID, func(p *FakePayment) error {
+		if p.Status != FakePaymentAuthorized {
+			return fmt.Errorf("cannot void %s payment", p.Status)
+		}
+		p.Status = FakePaymentVoided
+		return nil
+	})
+}
+
+// Refund implements PaymentProvider.
+func (f *FakePaymentProvider) Refund(ctx context.Context, authorizationID string, amount float32) error {
+	return f.update(ctx, authorizationID, func(p *FakePayment) error {
+		if p.Status != FakePaymentCaptured {
+			return fmt.Errorf("cannot refund %s payment", p.Status)
+		}
+		if p.Refunded+amount > p.Amount {
+			return errors.New("refund exceeds captured amount")
+		}
+		p.Refunded += amount
+		return nil
+	})
+}
+
+func (f *FakePaymentProvider) update(ctx context.Context, id string, fn func(*FakePayment) error) error {
+	if err := f.wait(ctx); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range f.payments {
+		if p.ID == id {
+			return fn(p)
+		}
+	}
+	return fmt.Errorf("payment %q not found", id)
+}
+
+// wait simulates network latency, giving up when ctx is done.
+func (f *FakePaymentProvider) wait(ctx context.Context) error {
+	if f.Latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(f.Latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,135 @@
+package ticketing_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+func assertNoSeatsTaken(t *testing.T, client ticketingv1.TrainTicketingServiceClient) {
+	t.Helper()
+	admin, err := client.ViewAdminDetails(context.Background(), connect.NewRequest(&v1.ViewAdminDetailsRequest{}))
+	if err != nil {
+		t.Fatalf("ViewAdminDetails failed: %v", err)
+	}
+	if len(admin.Msg.GetAdminView().GetSeats()) != 0 {
+		t.Fatalf("expected seat allocation to be rolled back, got %v", admin.Msg.GetAdminView().GetSeats())
+	}
+}
+
+func TestPurchaseTicketCapturesPayment(t *testing.T) {
+	provider := &server.FakePaymentProvider{}
+	client := newTestClient(t, server.WithPaymentProvider(provider))
+
+	receipt, err := purchase(client, "jane@example.com", paidWith("tok_visa"))
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	if receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		t.Fatalf("expected confirmed booking, got %v", receipt.GetStatus())
+	}
+
+	payments := provider.Payments()
+	if len(payments) != 1 || payments[0].Status != server.FakePaymentCaptured || payments[0].Amount != 20 || payments[0].Token != "tok_visa" {
+		t.Fatalf("expected one captured payment of 20, got %+v", payments)
+	}
+
+	// Cancelling well before departure refunds the whole payment
+	_, err = client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{
+		BookingId: receipt.GetBookingId(),
+	}))
+	if err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if refunded := provider.Payments()[0].Refunded; refunded != 20 {
+		t.Fatalf("expected 20 refunded, got %v", refunded)
+	}
+}
+
+func TestPurchaseTicketDeclinedPaymentReleasesSeat(t *testing.T) {
+	provider := &server.FakePaymentProvider{DeclineTokens: []string{"tok_declined"}}
+	client := newTestClient(t, server.WithPaymentProvider(provider))
+
+	_, err := purchase(client, "jane@example.com", paidWith("tok_declined"))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for declined payment, got %v", err)
+	}
+	assertNoSeatsTaken(t, client)
+	if len(provider.Payments()) != 0 {
+		t.Fatalf("expected no payments, got %+v", provider.Payments())
+	}
+
+	// The receipt lookup doesn't find the failed purchase
+	_, err = client.ViewReceipt(context.Background(), connect.NewRequest(&v1.ViewReceiptRequest{
+		Ticket: &v1.Ticket{User: &v1.User{FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"}},
+	}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestPurchaseTicketReportsFailureToReleaseSeat(t *testing.T) {
+	provider := &server.FakePaymentProvider{DeclineTokens: []string{"tok_declined"}}
+	ledger := &failingLedger{fail: func(event *v1.LedgerEvent) bool { return event.GetHoldReleased() != nil }}
+	client := newTestClient(t, server.WithPaymentProvider(provider), server.WithLedger(ledger))
+
+	// The payment's failure decides the code, and the release's is reported
+	// with it
+	_, err := purchase(client, "jane@example.com", paidWith("tok_declined"))
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition || !strings.Contains(err.Error(), "failed to release the seat") {
+		t.Fatalf("expected FailedPrecondition mentioning the release, got %v", err)
+	}
+}
+
+func TestPurchaseTicketPaymentTimeoutReleasesSeat(t *testing.T) {
+	provider := &server.FakePaymentProvider{Latency: time.Second}
+	client := newTestClient(t, server.WithPaymentProvider(provider), server.WithPaymentTimeout(20*time.Millisecond))
+
+	_, err := purchase(client, "jane@example.com", paidWith("tok_visa"))
+	if connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	assertNoSeatsTaken(t, client)
+}
+
+func TestExchangeTicketChargesFareDifference(t *testing.T) {
+	provider := &server.FakePaymentProvider{}
+	client := newTestClient(t, server.WithPaymentProvider(provider), testDepartures(time.Now().Add(48*time.Hour)))
+
+	receipt, err := purchase(client, "jane@example.com", paidWith("tok_visa"))
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	exchange, err := client.ExchangeTicket(context.Background(), connect.NewRequest(&v1.ExchangeTicketRequest{
+		BookingId:   receipt.GetBookingId(),
+		DepartureId: "evening",
+	}))
+	if err != nil {
+		t.Fatalf("ExchangeTicket failed: %v", err)
+	}
+
+	payments := provider.Payments()
+	if len(payments) != 2 || payments[1].Amount != 10 || payments[1].Status != server.FakePaymentCaptured {
+		t.Fatalf("expected the fare difference to be captured, got %+v", payments)
+	}
+
+	// Cancelling the exchanged booking refunds both payments
+	_, err = client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{
+		BookingId: exchange.Msg.GetReceipt().GetBookingId(),
+	}))
+	if err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	payments = provider.Payments()
+	if payments[0].Refunded != 20 || payments[1].Refunded != 10 {
+		t.Fatalf("expected both payments refunded, got %+v", payments)
+	}
+}
@@ -0,0 +1,346 @@
+package ticketing
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DEFAULT_POSTGRES_TIMEOUT bounds ledger queries that aren't made on behalf
+// of a request.
+const DEFAULT_POSTGRES_TIMEOUT = 5 * time.Second
+
+// DEFAULT_SEAT_CLAIM_TTL is how long a claimed seat is kept for a booking
+// before another handler may claim it. It must be longer than the time
+// between claiming a seat and recording the booking, which includes taking
+// payment for exchanges.
+const DEFAULT_SEAT_CLAIM_TTL = time.Minute
+
+// postgresUniqueViolation is the SQLSTATE Postgres reports for a duplicate key.
+const postgresUniqueViolation = "23505"
+
+// postgresMigrationLock is the advisory lock held while migrations run, so
+// handlers starting at the same time don't apply them twice.
+const postgresMigrationLock = 7460843091
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// PostgresLedger is a Ledger kept in PostgreSQL and shared by every handler
+// connected to the same database. Alongside the events it keeps a table of
+// seats: handlers claim seats from it with SELECT ... FOR UPDATE SKIP LOCKED,
+// so purchases on different handlers are given different seats without
+// waiting for each other, and each event is checked against it so no seat is
+// ever booked twice.
+type PostgresLedger struct {
+	pool *pgxpool.Pool
+}
+
+// OpenPostgresLedger connects to the database at connString and applies any
+// migrations it is missing.
+func OpenPostgresLedger(ctx context.Context, connString string) (*PostgresLedger, error) {
+	pool, err := pgxpool.New(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	l := &PostgresLedger{pool: pool}
+	if err := l.Migrate(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Migrate applies the migrations in migrations/postgres that haven't been
+// applied yet, in order of their version number.
+func (l *PostgresLedger) Migrate(ctx context.Context) error {
+	files, err := fs.Glob(postgresMigrations, "migrations/postgres/*.sql")
+	if err != nil {
+		return err
+	}
+	migrations := make(map[int]string)
+	var versions []int
+	for _, file := range files {
+		name := file[strings.LastIndex(file, "/")+1:]
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("migration %s has no version number", name)
+		}
+		migrations[version] = file
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+
+	return pgx.BeginFunc(ctx, l.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, postgresMigrationLock); err != nil {
+			return fmt.Errorf("failed to lock migrations: %w", err)
+		}
+		_, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`)
+		if err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+
+		for _, version := range versions {
+			var applied bool
+			err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied)
+			if err != nil {
+				return err
+			}
+			if applied {
+				continue
+			}
+			sql, err := postgresMigrations.ReadFile(migrations[version])
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, string(sql)); err != nil {
+				return fmt.Errorf("migration %d failed: %w", version, err)
+			}
+			if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Append implements Ledger. The seats table is updated in the same
+// transaction, and the event is rejected with ErrLedgerConflict if its
+// sequence number is taken or it books a seat that isn't free.
+func (l *PostgresLedger) Append(event *v1.LedgerEvent) error {
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode ledger event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_POSTGRES_TIMEOUT)
+	defer cancel()
+
+	return pgx.BeginFunc(ctx, l.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `INSERT INTO ledger_events (sequence, occurred_at, event) VALUES ($1, $2, $3::jsonb)`,
+			event.GetSequence(), event.GetOccurredAt().AsTime(), string(data))
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgresUniqueViolation {
+			return fmt.Errorf("event %d: %w", event.GetSequence(), ErrLedgerConflict)
+		}
+		if err != nil {
+			return err
+		}
+		return updateSeats(ctx, tx, event)
+	})
+}
+
+// updateSeats moves bookings between rows of the seats table as event does
+// to the handler's seats.
+func updateSeats(ctx context.Context, tx pgx.Tx, event *v1.LedgerEvent) error {
+	switch e := event.GetEvent().(type) {
+	case *v1.LedgerEvent_SeatHeld:
+		ticket := e.SeatHeld.GetTicket()
+		return bookSeat(ctx, tx, ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber(), e.SeatHeld.GetBookingId())
+
+	case *v1.LedgerEvent_HoldReleased:
+		return releaseSeat(ctx, tx, e.HoldReleased.GetBookingId())
+
+	case *v1.LedgerEvent_SeatModified:
+		var departureID string
+		err := tx.QueryRow(ctx, `UPDATE seats SET booking_id = NULL WHERE booking_id = $1 RETURNING departure_id`,
+			e.SeatModified.GetBookingId()).Scan(&departureID)
+		if err != nil {
+			return fmt.Errorf("failed to find seat of booking %q: %w", e.SeatModified.GetBookingId(), err)
+		}
+		return bookSeat(ctx, tx, departureID, e.SeatModified.GetSeatNumber(), e.SeatModified.GetBookingId())
+
+	case *v1.LedgerEvent_BookingCancelled:
+		return releaseSeat(ctx, tx, e.BookingCancelled.GetBookingId())
+
+	case *v1.LedgerEvent_BookingRejected:
+		return releaseSeat(ctx, tx, e.BookingRejected.GetBookingId())
+
+	case *v1.LedgerEvent_BookingExchanged:
+		if err := releaseSeat(ctx, tx, e.BookingExchanged.GetBookingId()); err != nil {
+			return err
+		}
+		ticket := e.BookingExchanged.GetTicket()
+		return bookSeat(ctx, tx, ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber(), e.BookingExchanged.GetNewBookingId())
+
+	case *v1.LedgerEvent_BookingsImported:
+		for _, imported := range e.BookingsImported.GetBookings() {
+			ticket := imported.GetTicket()
+			if err := bookSeat(ctx, tx, ticket.GetDepartureId(), ticket.GetSeat().GetSeatNumber(), imported.GetBookingId()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bookSeat gives a seat to bookingID if it is free and not claimed for another
+// booking.
+func bookSeat(ctx context.Context, tx pgx.Tx, departureID string, seatNumber int32, bookingID string) error {
+	tag, err := tx.Exec(ctx, `UPDATE seats
+		SET booking_id = $3, claimed_by = NULL, claimed_until = NULL
+		WHERE departure_id = $1 AND seat_number = $2 AND booking_id IS NULL
+			AND (claimed_by IS NULL OR claimed_by = $3 OR claimed_until < now())`,
+		departureID, seatNumber, bookingID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("seat %d on departure %q is taken: %w", seatNumber, departureID, ErrLedgerConflict)
+	}
+	return nil
+}
+
+// releaseSeat frees the seat held by bookingID.
+func releaseSeat(ctx context.Context, tx pgx.Tx, bookingID string) error {
+	_, err := tx.Exec(ctx, `UPDATE seats SET booking_id = NULL WHERE booking_id = $1`, bookingID)
+	return err
+}
+
+// Replay implements Ledger.
+func (l *PostgresLedger) Replay(fn func(*v1.LedgerEvent) error) error {
+	return l.ReplaySince(0, fn)
+}
+
+// ReplaySince implements SharedLedger.
+func (l *PostgresLedger) ReplaySince(sequence int64, fn func(*v1.LedgerEvent) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_POSTGRES_TIMEOUT)
+	defer cancel()
+
+	rows, err := l.pool.Query(ctx, `SELECT sequence, event FROM ledger_events WHERE sequence > $1 ORDER BY sequence`, sequence)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			sequence int64
+			data     []byte
+		)
+		if err := rows.Scan(&sequence, &data); err != nil {
+			return err
+		}
+		event := &v1.LedgerEvent{}
+		if err := protojson.Unmarshal(data, event); err != nil {
+			return fmt.Errorf("ledger event %d: %w", sequence, err)
+		}
+		if err := fn(event); err != nil {
+			return fmt.Errorf("ledger event %d: %w", sequence, err)
+		}
+	}
+	return rows.Err()
+}
+
+// LoadSnapshot implements Snapshotter.
+func (l *PostgresLedger) LoadSnapshot() (*v1.Snapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_POSTGRES_TIMEOUT)
+	defer cancel()
+
+	var data []byte
+	err := l.pool.QueryRow(ctx, `SELECT snapshot FROM ledger_snapshots ORDER BY sequence DESC LIMIT 1`).Scan(&data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &v1.Snapshot{}
+	if err := proto.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("snapshot is corrupt: %w", err)
+	}
+	return snapshot, nil
+}
+
+// SaveSnapshot implements Snapshotter. Older snapshots are deleted; the events
+// are kept as the booking history.
+func (l *PostgresLedger) SaveSnapshot(snapshot *v1.Snapshot) error {
+	data, err := proto.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DEFAULT_POSTGRES_TIMEOUT)
+	defer cancel()
+
+	return pgx.BeginFunc(ctx, l.pool, func(tx pgx.Tx) error {
+		// Handlers sharing the ledger may snapshot the same event
+		_, err := tx.Exec(ctx, `INSERT INTO ledger_snapshots (sequence, taken_at, snapshot) VALUES ($1, $2, $3)
+			ON CONFLICT (sequence) DO NOTHING`,
+			snapshot.GetSequence(), snapshot.GetTakenAt().AsTime(), data)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM ledger_snapshots WHERE sequence < $1`, snapshot.GetSequence())
+		return err
+	})
+}
+
+// AddSeats implements SeatAllocator.
+func (l *PostgresLedger) AddSeats(ctx context.Context, departureID string, seats []*v1.Seat) error {
+	numbers := make([]int32, len(seats))
+	sections := make([]int32, len(seats))
+	for i, seat := range seats {
+		numbers[i] = seat.GetSeatNumber()
+		sections[i] = int32(sectionOf(seat.GetSeatNumber()))
+	}
+	_, err := l.pool.Exec(ctx, `INSERT INTO seats (departure_id, seat_number, section)
+		SELECT $1, s.seat_number, s.section FROM unnest($2::integer[], $3::integer[]) AS s (seat_number, section)
+		ON CONFLICT (departure_id, seat_number) DO NOTHING`,
+		departureID, numbers, sections)
+	return err
+}
+
+// ClaimSeat implements SeatAllocator. Seats locked by another handler's claim
+// are skipped rather than waited for.
+func (l *PostgresLedger) ClaimSeat(ctx context.Context, departureID string, section v1.Section_SectionType, bookingID string) (int32, error) {
+	var number int32
+	err := pgx.BeginFunc(ctx, l.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `SELECT seat_number FROM seats
+			WHERE departure_id = $1 AND claimed_by = $2 AND claimed_until > now() AND booking_id IS NULL`,
+			departureID, bookingID).Scan(&number)
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, `UPDATE seats
+			SET claimed_by = $3, claimed_until = now() + $4::float8 * interval '1 second'
+			WHERE (departure_id, seat_number) = (
+				SELECT departure_id, seat_number FROM seats
+				WHERE departure_id = $1 AND booking_id IS NULL
+					AND (claimed_until IS NULL OR claimed_until < now())
+				ORDER BY (section = $2) DESC, seat_number
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING seat_number`,
+			departureID, int32(section), bookingID, DEFAULT_SEAT_CLAIM_TTL.Seconds()).Scan(&number)
+		if errors.Is(err, pgx.ErrNoRows) {
+			number = 0
+			return nil
+		}
+		return err
+	})
+	return number, err
+}
+
+// Close closes the connections to the database.
+func (l *PostgresLedger) Close() {
+	l.pool.Close()
+}
@@ -0,0 +1,247 @@
+//go:build postgres
+
+package ticketing_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/jackc/pgx/v5"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// The Postgres tests only build with the postgres tag, and fail rather than
+// skip when they can't reach a server:
+//
+//	go test -tags postgres ./...
+//
+// postgresURLEnv names the variable that points them at an existing server.
+// When it isn't set an embedded server is downloaded and started.
+const postgresURLEnv = "TICKETING_POSTGRES_URL"
+
+var (
+	embeddedOnce sync.Once
+	embeddedURL  string
+	embeddedErr  error
+)
+
+// postgresServerURL returns the URL of the server to test against.
+func postgresServerURL(t *testing.T) string {
+	t.Helper()
+	if url := os.Getenv(postgresURLEnv); url != "" {
+		return url
+	}
+	embeddedOnce.Do(func() {
+		listener, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			embeddedErr = err
+			return
+		}
+		port := uint32(listener.Addr().(*net.TCPAddr).Port)
+		listener.Close()
+
+		dir, err := os.MkdirTemp("", "ticketing-postgres")
+		if err != nil {
+			embeddedErr = err
+			return
+		}
+		config := embeddedpostgres.DefaultConfig().
+			Port(port).
+			RuntimePath(dir).
+			StartTimeout(30 * time.Second).
+			Logger(io.Discard)
+		db := embeddedpostgres.NewDatabase(config)
+		if embeddedErr = db.Start(); embeddedErr != nil {
+			return
+		}
+		afterTests = append(afterTests, func() { db.Stop() })
+		embeddedURL = config.GetConnectionURL() + "?sslmode=disable"
+	})
+	if embeddedErr != nil {
+		t.Fatalf("failed to start an embedded Postgres (%v); set %s to use another server", embeddedErr, postgresURLEnv)
+	}
+	return embeddedURL
+}
+
+// newPostgresSchema creates an empty schema for the test and returns a URL
+// that uses it.
+func newPostgresSchema(t *testing.T) string {
+	t.Helper()
+	serverURL := postgresServerURL(t)
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, serverURL)
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	schema := "test_" + strings.ToLower(strings.NewReplacer("/", "_", "-", "_").Replace(t.Name()))
+	if _, err := conn.Exec(ctx, fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE; CREATE SCHEMA %s", schema, schema)); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		conn, err := pgx.Connect(context.Background(), serverURL)
+		if err != nil {
+			return
+		}
+		defer conn.Close(context.Background())
+		conn.Exec(context.Background(), fmt.Sprintf("DROP SCHEMA %s CASCADE", schema))
+	})
+
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatalf("invalid postgres URL: %v", err)
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// openTestPostgres connects to the database at url until the test ends, as
+// another instance of the service would.
+func openTestPostgres(t *testing.T, url string) *server.PostgresLedger {
+	t.Helper()
+	ledger, err := server.OpenPostgresLedger(context.Background(), url)
+	if err != nil {
+		t.Fatalf("OpenPostgresLedger failed: %v", err)
+	}
+	t.Cleanup(ledger.Close)
+	return ledger
+}
+
+func TestPostgresLedgerSharedBetweenHandlers(t *testing.T) {
+	url := newPostgresSchema(t)
+	departure := server.WithDepartureTime(time.Now().Add(48 * time.Hour).Truncate(time.Second))
+	first := newTestClient(t, departure, server.WithSnapshotInterval(3), server.WithLedger(openTestPostgres(t, url)))
+	second := newTestClient(t, departure, server.WithSnapshotInterval(3), server.WithLedger(openTestPostgres(t, url)))
+
+	// Each instance sees the seats the other has sold
+	purchaseTicket(t, first, "Jane", "Roe", "jane@example.com")
+	johnID := purchaseTicket(t, second, "John", "Doe", "john@example.com")
+	seats := viewAdminSeats(t, first)
+	if len(seats) != 2 || seats[1].GetSeatNumber() != 2 || seats[1].GetUser().GetFirstName() != "John" {
+		t.Fatalf("expected John in seat 2, got %v", seats)
+	}
+
+	if _, err := second.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "Jane"},
+		NewSeatNumber: 15,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	if _, err := first.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: johnID})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	want := viewAdminSeats(t, second)
+	if len(want) != 1 || want[0].GetSeatNumber() != 15 {
+		t.Fatalf("expected only Jane in seat 15, got %v", want)
+	}
+
+	// A new instance starts from the latest snapshot and the events after it
+	third := newTestClient(t, departure, server.WithLedger(openTestPostgres(t, url)))
+	assertSameSeats(t, want, viewAdminSeats(t, third))
+}
+
+func TestPostgresConcurrentPurchasesNeverShareSeats(t *testing.T) {
+	url := newPostgresSchema(t)
+	departure := server.WithDepartureTime(time.Now().Add(48 * time.Hour).Truncate(time.Second))
+	clients := []ticketingv1.TrainTicketingServiceClient{
+		newTestClient(t, departure, server.WithLedger(openTestPostgres(t, url))),
+		newTestClient(t, departure, server.WithLedger(openTestPostgres(t, url))),
+	}
+
+	// More buyers than seats, spread over two instances
+	const buyers = 30
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		purchased = make(map[string]int32)
+		failures  = make(map[connect.Code]int)
+	)
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("buyer%d@example.com", i)
+			res, err := clients[i%len(clients)].PurchaseTicket(context.Background(), connect.NewRequest(&v1.PurchaseTicketRequest{
+				Ticket: &v1.Ticket{User: &v1.User{FirstName: fmt.Sprintf("Buyer%d", i), LastName: "Lee", Email: email}},
+			}))
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[connect.CodeOf(err)]++
+				return
+			}
+			purchased[email] = res.Msg.GetReceipt().GetTicket().GetSeat().GetSeatNumber()
+		}(i)
+	}
+	wg.Wait()
+
+	for code, n := range failures {
+		if code != connect.CodeResourceExhausted && code != connect.CodeAborted {
+			t.Fatalf("unexpected failure %v for %d purchases", code, n)
+		}
+	}
+	if len(purchased) == 0 || len(purchased) > 20 {
+		t.Fatalf("expected between 1 and 20 purchases, got %d", len(purchased))
+	}
+
+	// Every successful buyer has a seat of their own
+	seats := viewAdminSeats(t, newTestClient(t, departure, server.WithLedger(openTestPostgres(t, url))))
+	if len(seats) != len(purchased) {
+		t.Fatalf("expected %d seated users, got %d", len(purchased), len(seats))
+	}
+	for _, seat := range seats {
+		if purchased[seat.GetUser().GetEmail()] != seat.GetSeatNumber() {
+			t.Fatalf("seat %d is held by %s, whose receipt says seat %d", seat.GetSeatNumber(), seat.GetUser().GetEmail(), purchased[seat.GetUser().GetEmail()])
+		}
+	}
+}
+
+func TestPostgresImportClaimsSeats(t *testing.T) {
+	url := newPostgresSchema(t)
+	departure := server.WithDepartureTime(time.Now().Add(48 * time.Hour).Truncate(time.Second))
+	client := newTestClient(t, departure, server.WithLedger(openTestPostgres(t, url)))
+
+	// Another instance has claimed seat 1 for a purchase it is still taking
+	// payment for, which this one can't see
+	claimed, err := openTestPostgres(t, url).ClaimSeat(context.Background(), server.DEFAULT_DEPARTURE_ID, v1.Section_SECTION_TYPE_A, "elsewhere")
+	if err != nil || claimed != 1 {
+		t.Fatalf("expected seat 1 to be claimed, got %d, %v", claimed, err)
+	}
+
+	res := importBookings(t, client, false, []*v1.ImportBookingRow{importRow("Ann", server.DEFAULT_DEPARTURE_ID, v1.Section_SECTION_TYPE_UNSPECIFIED, 0)})
+	if seats := importedSeats(res); len(seats) != 1 || seats[0] != 2 {
+		t.Fatalf("expected the import to claim seat 2, got %v", seats)
+	}
+}
+
+func TestPostgresMigrationsAreIdempotent(t *testing.T) {
+	url := newPostgresSchema(t)
+	ledger, err := server.OpenPostgresLedger(context.Background(), url)
+	if err != nil {
+		t.Fatalf("OpenPostgresLedger failed: %v", err)
+	}
+	defer ledger.Close()
+
+	if err := ledger.Migrate(context.Background()); err != nil {
+		t.Fatalf("second Migrate failed: %v", err)
+	}
+}
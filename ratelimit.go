@@ -0,0 +1,213 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RATE_LIMIT_SWEEP_INTERVAL is how often limiters that have refilled are
+// forgotten, so clients that have gone away don't take up memory.
+const RATE_LIMIT_SWEEP_INTERVAL = time.Minute
+
+// API_KEY_HEADER is the header LimitByAPIKey reads by default.
+const API_KEY_HEADER = "X-Api-Key"
+
+// RateLimit is how often a client may call a method: Rate calls a second on
+// average, with bursts of up to Burst calls. A zero Rate with a zero Burst
+// turns every call away.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimits are the limits each client is held to. Methods, such as
+// PurchaseTicket, are limited by their entry in Methods, or Default when they
+// have none. Each method has its own bucket, so a client that has used up its
+// purchases can still look up its receipts.
+type RateLimits struct {
+	Default RateLimit
+	Methods map[string]RateLimit
+}
+
+// DefaultRateLimits let a client make 20 calls a second, but buy only one
+// ticket a second after a burst of 5, as holding seats is what scripts
+// exhaust the train with.
+var DefaultRateLimits = RateLimits{
+	Default: RateLimit{Rate: 20, Burst: 40},
+	Methods: map[string]RateLimit{
+		"PurchaseTicket": {Rate: 1, Burst: 5},
+	},
+}
+
+// limit returns the limit of a method.
+func (l RateLimits) limit(method string) RateLimit {
+	if limit, ok := l.Methods[method]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// RateLimitKey names the client a request is counted against.
+type RateLimitKey func(ctx context.Context, peer connect.Peer, header http.Header) string
+
+// LimitBySubject counts requests against the subject of the caller's JWT, or
+// their IP address when the Authenticator doesn't identify callers.
+func LimitBySubject() RateLimitKey {
+	return func(ctx context.Context, peer connect.Peer, header http.Header) string {
+		if identity := IdentityFrom(ctx); identity != nil && identity.Subject != "" {
+			return "subject:" + identity.Subject
+		}
+		return clientIP(peer)
+	}
+}
+
+// LimitByClientIP counts requests against the IP address they came from.
+// Behind a proxy that is the proxy's address, so use another key there.
+func LimitByClientIP() RateLimitKey {
+	return func(_ context.Context, peer connect.Peer, _ http.Header) string {
+		return clientIP(peer)
+	}
+}
+
+// LimitByAPIKey counts requests against the API key in header, which is
+// API_KEY_HEADER when empty, or their IP address when they have none.
+func LimitByAPIKey(header string) RateLimitKey {
+	if header == "" {
+		header = API_KEY_HEADER
+	}
+	return func(_ context.Context, peer connect.Peer, h http.Header) string {
+		if key := h.Get(header); key != "" {
+			return "key:" + key
+		}
+		return clientIP(peer)
+	}
+}
+
+func clientIP(peer connect.Peer) string {
+	return "ip:" + peerHost(peer)
+}
+
+// peerHost returns the IP address of a peer without its port.
+func peerHost(peer connect.Peer) string {
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		return peer.Addr
+	}
+	return host
+}
+
+// WithRateLimits holds each client, as named by key, to limits. Calls over
+// the limit fail with ResourceExhausted, carrying a RetryInfo detail and a
+// Retry-After header that say when to try again. Requests forwarded by
+// another cluster member were limited by that member, so they aren't counted
+// again. By default calls aren't limited.
+func WithRateLimits(limits RateLimits, key RateLimitKey) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.rateLimiter = &rateLimiter{
+			limits:   limits,
+			key:      key,
+			limiters: make(map[string]*rate.Limiter),
+		}
+	}
+}
+
+// rateLimiter keeps a token bucket for each client and method.
+type rateLimiter struct {
+	limits    RateLimits
+	key       RateLimitKey
+	now       func() time.Time
+	mu        sync.Mutex
+	limiters  map[string]*rate.Limiter // By client key and method
+	lastSweep time.Time
+}
+
+// allow takes a token from the bucket of the client calling procedure, or
+// returns a ResourceExhausted error saying how long until there is one.
+func (l *rateLimiter) allow(ctx context.Context, procedure string, peer connect.Peer, header http.Header) error {
+	if header.Get(FORWARDED_BY_HEADER) != "" {
+		return nil
+	}
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	client := l.key(ctx, peer, header)
+	now := l.now()
+
+	l.mu.Lock()
+	l.sweep(now)
+	limiter, ok := l.limiters[client+" "+method]
+	if !ok {
+		limit := l.limits.limit(method)
+		limiter = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+		l.limiters[client+" "+method] = limiter
+	}
+	l.mu.Unlock()
+
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("%s is not allowed", method))
+	}
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	reservation.CancelAt(now)
+
+	err := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("too many %s calls, retry in %s", method, delay.Round(time.Millisecond)))
+	if detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); detailErr == nil {
+		err.AddDetail(detail)
+	}
+	err.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	return err
+}
+
+// sweep forgets the limiters whose buckets are full again, as they behave
+// just like new ones. The caller must hold l.mu.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < RATE_LIMIT_SWEEP_INTERVAL {
+		return
+	}
+	l.lastSweep = now
+	for key, limiter := range l.limiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(l.limiters, key)
+		}
+	}
+}
+
+// WrapUnary implements connect.Interceptor.
+func (l *rateLimiter) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := l.allow(ctx, req.Spec().Procedure, req.Peer(), req.Header()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor. The handler makes no
+// streaming calls, so there's nothing to limit.
+func (l *rateLimiter) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor. A stream counts as one
+// call, however many messages it carries.
+func (l *rateLimiter) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := l.allow(ctx, conn.Spec().Procedure, conn.Peer(), conn.RequestHeader()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
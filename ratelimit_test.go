@@ -0,0 +1,147 @@
+package ticketing_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// retryDelay returns the delay in the RetryInfo detail of err.
+func retryDelay(t *testing.T, err error) time.Duration {
+	t.Helper()
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	for _, detail := range connectErr.Details() {
+		msg, err := detail.Value()
+		if err != nil {
+			t.Fatalf("Value failed: %v", err)
+		}
+		if info, ok := msg.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	t.Fatal("no RetryInfo detail")
+	return 0
+}
+
+func TestRateLimits(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	client := newTestClient(t, server.WithClock(clock.Now), server.WithRateLimits(server.RateLimits{
+		Default: server.RateLimit{Rate: 10, Burst: 3},
+		Methods: map[string]server.RateLimit{"PurchaseTicket": {Rate: 0.5, Burst: 2}},
+	}, server.LimitByClientIP()))
+
+	for i := 0; i < 2; i++ {
+		if _, err := purchase(client, fmt.Sprintf("jane%d@example.com", i)); err != nil {
+			t.Fatalf("PurchaseTicket %d failed: %v", i, err)
+		}
+	}
+	_, err := purchase(client, "jane2@example.com")
+	if delay := retryDelay(t, err); delay != 2*time.Second {
+		t.Fatalf("expected to retry in 2s, got %v", delay)
+	}
+	if retryAfter := err.(*connect.Error).Meta().Get("Retry-After"); retryAfter != "2" {
+		t.Fatalf("expected Retry-After 2, got %q", retryAfter)
+	}
+	if seats := takenSeats(t, client, ""); len(seats) != 2 {
+		t.Fatalf("expected the limited purchase to take no seat, got %v", seats)
+	}
+
+	// Other methods have buckets of their own
+	for i := 0; i < 2; i++ {
+		takenSeats(t, client, "")
+	}
+	if _, err := client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{})); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected the fourth seat map to be limited, got %v", err)
+	}
+
+	// Turned away calls don't use up the bucket, so it refills on time
+	clock.Advance(time.Second)
+	_, err = purchase(client, "jane2@example.com")
+	if delay := retryDelay(t, err); delay != time.Second {
+		t.Fatalf("expected to retry in 1s, got %v", delay)
+	}
+	clock.Advance(time.Second)
+	if _, err := purchase(client, "jane2@example.com"); err != nil {
+		t.Fatalf("expected the purchase to be allowed once the bucket refilled, got %v", err)
+	}
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	limits := server.RateLimits{Default: server.RateLimit{Rate: 1, Burst: 1}}
+	clock := server.WithClock(func() time.Time { return time.Unix(1700000000, 0) })
+
+	// Callers are told apart by their JWT's subject
+	_, srv := startServer(t, clock, server.WithAuthenticator(server.JWT(testJWTKey)), server.WithRateLimits(limits, server.LimitBySubject()))
+	alice := newClient(srv, signJWT(t, "alice"))
+	bob := newClient(srv, signJWT(t, "bob"))
+	if _, err := purchase(alice, "alice@example.com"); err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	if _, err := purchase(bob, "bob@example.com"); err != nil {
+		t.Fatalf("expected bob to have a limit of their own, got %v", err)
+	}
+	if _, err := purchase(alice, "alice2@example.com"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected alice to be limited, got %v", err)
+	}
+
+	// Or by API key, falling back to the client's address
+	_, srv = startServer(t, clock, server.WithRateLimits(limits, server.LimitByAPIKey("")))
+	withKey := func(key string) ticketingv1.TrainTicketingServiceClient {
+		return newClient(srv, "", connect.WithInterceptors(connect.UnaryInterceptorFunc(
+			func(next connect.UnaryFunc) connect.UnaryFunc {
+				return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+					req.Header().Set("Authorization", "Bearer "+server.DEMO_AUTH_TOKEN)
+					if key != "" {
+						req.Header().Set(server.API_KEY_HEADER, key)
+					}
+					return next(ctx, req)
+				}
+			})))
+	}
+	for i, tc := range []struct {
+		key  string
+		want connect.Code
+	}{
+		{"key-1", 0},
+		{"key-2", 0},
+		{"key-1", connect.CodeResourceExhausted},
+		{"", 0},
+		{"", connect.CodeResourceExhausted},
+	} {
+		var code connect.Code
+		if _, err := purchase(withKey(tc.key), fmt.Sprintf("user%d@example.com", i)); err != nil {
+			code = connect.CodeOf(err)
+		}
+		if code != tc.want {
+			t.Fatalf("call %d with key %q: expected %v, got %v", i, tc.key, tc.want, code)
+		}
+	}
+}
+
+func TestRateLimitsApplyToForgedForwards(t *testing.T) {
+	// Only requests from cluster members skip the limits, and this handler
+	// has none
+	_, srv := startServer(t, server.WithRateLimits(server.RateLimits{Default: server.RateLimit{Rate: 1, Burst: 1}}, server.LimitByClientIP()))
+	client := newClient(srv, server.DEMO_AUTH_TOKEN,
+		withHeader(server.FORWARDED_BY_HEADER, "http://member"),
+		withHeader(server.CLUSTER_SECRET_HEADER, "guess"))
+	if _, err := purchase(client, "jane@example.com"); err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	if _, err := purchase(client, "jane2@example.com"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected a forged forward to be limited, got %v", err)
+	}
+}
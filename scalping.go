@@ -0,0 +1,248 @@
+package ticketing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// CLIENT_IP_HEADER carries the address of the client a forwarded request came
+// from, as the receiving member only sees the member that forwarded it.
+const CLIENT_IP_HEADER = "Ticketing-Client-Ip"
+
+// ScalpingRules keep scripts from buying up a departure to resell its seats.
+// Each limit counts the bookings on one departure that hold a seat, and is
+// turned off when zero.
+type ScalpingRules struct {
+	// Bookings an account, the subject of the caller's JWT, may make. Further
+	// purchases are turned away.
+	MaxBookingsPerAccount int
+	// Bookings made for one passenger email. Further purchases are turned
+	// away.
+	MaxBookingsPerEmail int
+	// Bookings one payment method may pay for before further purchases with
+	// it are held for an admin's review.
+	ReviewBookingsPerPayment int
+	// Bookings one client IP address may make before further purchases from
+	// it are held for an admin's review.
+	ReviewBookingsPerIP int
+}
+
+// DefaultScalpingRules let an account or passenger book 6 seats on a train,
+// and hold purchases for review once a card has paid for 4 seats or an
+// address has booked 10, which leaves room for families and offices behind
+// one address.
+var DefaultScalpingRules = ScalpingRules{
+	MaxBookingsPerAccount:    6,
+	MaxBookingsPerEmail:      6,
+	ReviewBookingsPerPayment: 4,
+	ReviewBookingsPerIP:      10,
+}
+
+// WithScalpingRules holds purchases to rules. Purchases held for review keep
+// their seat but aren't paid for until an admin approves them with
+// ReviewBooking. By default purchases aren't limited.
+func WithScalpingRules(rules ScalpingRules) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.scalpingRules = rules
+	}
+}
+
+// purchaser is who is making a purchase, as far as the scalping rules can
+// tell.
+type purchaser struct {
+	account            string
+	clientIP           string
+	paymentFingerprint string
+}
+
+// newPurchaser identifies the caller making a purchase.
+func newPurchaser(ctx context.Context, peer connect.Peer, header http.Header, method *v1.PaymentMethod) purchaser {
+	p := purchaser{clientIP: requestIP(peer, header), paymentFingerprint: paymentFingerprint(method)}
+	if identity := IdentityFrom(ctx); identity != nil {
+		p.account = identity.Subject
+	}
+	return p
+}
+
+// requestIP returns the address of the client that sent a request, taking it
+// from CLIENT_IP_HEADER when another member forwarded the request. Clients
+// can't set either header themselves, as withPeerHeaders drops them from
+// requests without the cluster's secret.
+func requestIP(peer connect.Peer, header http.Header) string {
+	if ip := header.Get(CLIENT_IP_HEADER); ip != "" && header.Get(FORWARDED_BY_HEADER) != "" {
+		return ip
+	}
+	return peerHost(peer)
+}
+
+// paymentFingerprint identifies a payment method without keeping its token,
+// or returns "" when there is no token to identify it by.
+func paymentFingerprint(method *v1.PaymentMethod) string {
+	if method.GetToken() == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(method.GetToken()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// screen checks a purchase of a seat on a departure against the scalping
+// rules. It returns an error if the purchase must be turned away, and why it
+// must be held for review if it must. The caller must hold h.mu for reading.
+func (h *MyTrainTicketingServiceHandler) screen(d *departure, user *v1.User, p purchaser) (string, error) {
+	rules := h.scalpingRules
+	var accountBookings, emailBookings, paymentBookings, ipBookings int
+	for _, b := range d.bookings {
+		if !b.holdsSeat() {
+			continue
+		}
+		if p.account != "" && b.account == p.account {
+			accountBookings++
+		}
+		if strings.EqualFold(b.ticket.GetUser().GetEmail(), user.GetEmail()) {
+			emailBookings++
+		}
+		if p.paymentFingerprint != "" && b.fingerprint == p.paymentFingerprint {
+			paymentBookings++
+		}
+		if p.clientIP != "" && b.clientIP == p.clientIP {
+			ipBookings++
+		}
+	}
+
+	if rules.MaxBookingsPerAccount > 0 && accountBookings >= rules.MaxBookingsPerAccount {
+		return "", connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("account %s already has %d bookings on this departure", p.account, accountBookings))
+	}
+	if rules.MaxBookingsPerEmail > 0 && emailBookings >= rules.MaxBookingsPerEmail {
+		return "", connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("%s already has %d bookings on this departure", user.GetEmail(), emailBookings))
+	}
+	if rules.ReviewBookingsPerPayment > 0 && paymentBookings >= rules.ReviewBookingsPerPayment {
+		return fmt.Sprintf("payment method already paid for %d bookings on the departure", paymentBookings), nil
+	}
+	if rules.ReviewBookingsPerIP > 0 && ipBookings >= rules.ReviewBookingsPerIP {
+		return fmt.Sprintf("client IP %s already made %d bookings on the departure", p.clientIP, ipBookings), nil
+	}
+	return "", nil
+}
+
+// holdsSeat reports whether a booking is sitting in its seat.
+func (b *booking) holdsSeat() bool {
+	switch b.status {
+	case v1.BookingStatus_BOOKING_STATUS_HELD, v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW, v1.BookingStatus_BOOKING_STATUS_CONFIRMED:
+		return true
+	}
+	return false
+}
+
+// ListBookingReviews implements the ListBookingReviews method of TrainTicketingServiceHandler.
+// It lists the purchases held for review, oldest first. Only admins may list
+// them.
+func (h *MyTrainTicketingServiceHandler) ListBookingReviews(ctx context.Context, req *connect.Request[v1.ListBookingReviewsRequest]) (*connect.Response[v1.ListBookingReviewsResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Take a read lock so other reads can go ahead at the same time
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	defer h.mu.RUnlock()
+
+	var pending []*booking
+	for _, b := range h.bookings {
+		if b.status != v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW {
+			continue
+		}
+		if departureID := req.Msg.GetDepartureId(); departureID != "" && b.ticket.GetDepartureId() != departureID {
+			continue
+		}
+		pending = append(pending, b)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].purchasedAt.Equal(pending[j].purchasedAt) {
+			return pending[i].purchasedAt.Before(pending[j].purchasedAt)
+		}
+		return pending[i].id < pending[j].id
+	})
+
+	response := &v1.ListBookingReviewsResponse{}
+	for _, b := range pending {
+		response.Reviews = append(response.Reviews, &v1.BookingReview{
+			Booking:            b.receipt(),
+			Reason:             b.reviewReason,
+			Account:            b.account,
+			ClientIp:           b.clientIP,
+			PaymentFingerprint: b.fingerprint,
+		})
+	}
+	return connect.NewResponse(response), nil
+}
+
+// ReviewBooking implements the ReviewBooking method of TrainTicketingServiceHandler.
+// Approving a booking takes payment for it and confirms it; if the payment
+// fails the booking stays in review. Rejecting it frees the seat. Only admins
+// may review bookings.
+func (h *MyTrainTicketingServiceHandler) ReviewBooking(ctx context.Context, req *connect.Request[v1.ReviewBookingRequest]) (*connect.Response[v1.ReviewBookingResponse], error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.Msg.GetBookingId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("booking ID is required"))
+	}
+	decision := req.Msg.GetDecision()
+	if decision != v1.ReviewDecision_REVIEW_DECISION_APPROVE && decision != v1.ReviewDecision_REVIEW_DECISION_REJECT {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("decision must be approve or reject"))
+	}
+
+	// Lock the booking's departure and the maps
+	bookings, unlock, err := h.lockBookings(ctx, func() ([]*booking, error) {
+		b, ok := h.bookings[req.Msg.GetBookingId()]
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("booking not found"))
+		}
+		return []*booking{b}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	b := bookings[0]
+
+	if b.status != v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("only bookings pending review can be reviewed"))
+	}
+	if err := b.checkVersion(req.Msg.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+
+	if decision == v1.ReviewDecision_REVIEW_DECISION_REJECT {
+		err := h.record(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_BookingRejected{BookingRejected: &v1.BookingRejected{BookingId: b.id}}})
+		if err != nil {
+			return nil, err
+		}
+		return connect.NewResponse(&v1.ReviewBookingResponse{Receipt: b.receipt()}), nil
+	}
+
+	// Take payment without holding the maps; the departure's lock keeps the
+	// booking as it is
+	var payments []*v1.Payment
+	h.unlocked(func() {
+		payments, err = h.charge(ctx, b.paymentMethod, b.ticket.GetPricePaid())
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := h.confirm(ctx, b, payments); err != nil {
+		_, _ = h.refund(context.WithoutCancel(ctx), payments, b.ticket.GetPricePaid())
+		return nil, err
+	}
+	return connect.NewResponse(&v1.ReviewBookingResponse{Receipt: b.receipt()}), nil
+}
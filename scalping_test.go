@@ -0,0 +1,214 @@
+package ticketing_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+func listBookingReviews(t *testing.T, client ticketingv1.TrainTicketingServiceClient) []*v1.BookingReview {
+	t.Helper()
+	res, err := client.ListBookingReviews(context.Background(), connect.NewRequest(&v1.ListBookingReviewsRequest{}))
+	if err != nil {
+		t.Fatalf("ListBookingReviews failed: %v", err)
+	}
+	return res.Msg.GetReviews()
+}
+
+func reviewBooking(client ticketingv1.TrainTicketingServiceClient, bookingID string, decision v1.ReviewDecision) (*v1.Receipt, error) {
+	res, err := client.ReviewBooking(context.Background(), connect.NewRequest(&v1.ReviewBookingRequest{BookingId: bookingID, Decision: decision}))
+	if err != nil {
+		return nil, err
+	}
+	return res.Msg.GetReceipt(), nil
+}
+
+func TestScalpingCaps(t *testing.T) {
+	// Passengers may only book so many seats, however they write their email
+	client := newTestClient(t, server.WithScalpingRules(server.ScalpingRules{MaxBookingsPerEmail: 2}))
+	var first *v1.Receipt
+	for i := 0; i < 2; i++ {
+		receipt, err := purchase(client, "jane@example.com")
+		if err != nil {
+			t.Fatalf("PurchaseTicket %d failed: %v", i, err)
+		}
+		first = receipt
+	}
+	if _, err := purchase(client, "Jane@Example.com"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected the third booking to be turned away, got %v", err)
+	}
+	if seats := takenSeats(t, client, ""); len(seats) != 2 {
+		t.Fatalf("expected the turned away purchase to take no seat, got %v", seats)
+	}
+
+	// Cancelled bookings don't count
+	if _, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: first.GetBookingId()})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if _, err := purchase(client, "jane@example.com"); err != nil {
+		t.Fatalf("expected a booking once one was cancelled, got %v", err)
+	}
+
+	// Accounts are told apart by their JWT's subject
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)), server.WithScalpingRules(server.ScalpingRules{MaxBookingsPerAccount: 2}))
+	alice := newClient(srv, signJWT(t, "alice"))
+	bob := newClient(srv, signJWT(t, "bob"))
+	for _, email := range []string{"a1@example.com", "a2@example.com"} {
+		if _, err := purchase(alice, email); err != nil {
+			t.Fatalf("PurchaseTicket for %s failed: %v", email, err)
+		}
+	}
+	if _, err := purchase(alice, "a3@example.com"); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected alice's third booking to be turned away, got %v", err)
+	}
+	if _, err := purchase(bob, "b1@example.com"); err != nil {
+		t.Fatalf("expected bob to have a cap of their own, got %v", err)
+	}
+}
+
+func TestBookingReviews(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.jsonl")
+	provider := &server.FakePaymentProvider{}
+	open := func() ticketingv1.TrainTicketingServiceClient {
+		ledger, err := server.OpenFileLedger(path)
+		if err != nil {
+			t.Fatalf("OpenFileLedger failed: %v", err)
+		}
+		t.Cleanup(func() { ledger.Close() })
+		return newTestClient(t, server.WithLedger(ledger), server.WithPaymentProvider(provider),
+			server.WithScalpingRules(server.ScalpingRules{ReviewBookingsPerPayment: 1}))
+	}
+	client := open()
+
+	if receipt, err := purchase(client, "jane@example.com", paidWith("tok_visa")); err != nil || receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		t.Fatalf("expected the first booking to be confirmed, got %v (%v)", receipt, err)
+	}
+
+	// Further bookings paid for with the same card wait for review, holding
+	// their seats without being charged
+	var held []string
+	for _, email := range []string{"john@example.com", "mary@example.com"} {
+		receipt, err := purchase(client, email, paidWith("tok_visa"))
+		if err != nil {
+			t.Fatalf("PurchaseTicket failed: %v", err)
+		}
+		if receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW {
+			t.Fatalf("expected the booking to be held for review, got %v", receipt.GetStatus())
+		}
+		held = append(held, receipt.GetBookingId())
+	}
+	if payments := provider.Payments(); len(payments) != 1 {
+		t.Fatalf("expected only the first booking to be charged, got %+v", payments)
+	}
+	if _, err := purchase(client, "paul@example.com", paidWith("tok_amex")); err != nil {
+		t.Fatalf("expected another card to be charged at once, got %v", err)
+	}
+
+	// Bookings in review survive a restart
+	client = open()
+	if seats := takenSeats(t, client, ""); len(seats) != 4 {
+		t.Fatalf("expected 4 taken seats, got %v", seats)
+	}
+	reviews := listBookingReviews(t, client)
+	if len(reviews) != 2 || reviews[0].GetBooking().GetBookingId() != held[0] || reviews[1].GetBooking().GetBookingId() != held[1] {
+		t.Fatalf("expected both held bookings oldest first, got %v", reviews)
+	}
+	review := reviews[0]
+	if !strings.Contains(review.GetReason(), "payment method") || review.GetClientIp() != "127.0.0.1" ||
+		review.GetPaymentFingerprint() == "" || strings.Contains(review.GetPaymentFingerprint(), "tok_visa") {
+		t.Fatalf("expected the review to say why the booking was held and by whom, got %v", review)
+	}
+
+	// Approving takes payment, rejecting frees the seat
+	receipt, err := reviewBooking(client, held[0], v1.ReviewDecision_REVIEW_DECISION_APPROVE)
+	if err != nil || receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		t.Fatalf("expected the approved booking to be confirmed, got %v (%v)", receipt, err)
+	}
+	if payments := provider.Payments(); len(payments) != 3 || payments[2].Token != "tok_visa" || payments[2].Status != server.FakePaymentCaptured {
+		t.Fatalf("expected the approved booking to be charged, got %+v", payments)
+	}
+	receipt, err = reviewBooking(client, held[1], v1.ReviewDecision_REVIEW_DECISION_REJECT)
+	if err != nil || receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_REJECTED {
+		t.Fatalf("expected the booking to be rejected, got %v (%v)", receipt, err)
+	}
+	if seats := takenSeats(t, client, ""); len(seats) != 3 {
+		t.Fatalf("expected the rejected booking's seat to be freed, got %v", seats)
+	}
+	if reviews := listBookingReviews(t, client); len(reviews) != 0 {
+		t.Fatalf("expected the queue to be empty, got %v", reviews)
+	}
+
+	if _, err := reviewBooking(client, held[1], v1.ReviewDecision_REVIEW_DECISION_APPROVE); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected FailedPrecondition reviewing a booking twice, got %v", err)
+	}
+	if _, err := reviewBooking(client, "missing", v1.ReviewDecision_REVIEW_DECISION_REJECT); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if _, err := reviewBooking(client, held[0], v1.ReviewDecision_REVIEW_DECISION_UNSPECIFIED); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument without a decision, got %v", err)
+	}
+}
+
+func TestScalpingByIPIgnoresForgedClientIP(t *testing.T) {
+	_, srv := startServer(t, server.WithScalpingRules(server.ScalpingRules{ReviewBookingsPerIP: 1}))
+	admin := newClient(srv, server.DEMO_AUTH_TOKEN)
+	if receipt, err := purchase(admin, "jane@example.com"); err != nil || receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		t.Fatalf("expected the first booking to be confirmed, got %v (%v)", receipt, err)
+	}
+
+	// Claiming a member forwarded the purchase for another address doesn't
+	// give the client a fresh count
+	forged := newClient(srv, server.DEMO_AUTH_TOKEN,
+		withHeader(server.FORWARDED_BY_HEADER, "http://member"),
+		withHeader(server.CLIENT_IP_HEADER, "203.0.113.7"))
+	receipt, err := purchase(forged, "john@example.com")
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	if receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW {
+		t.Fatalf("expected the booking to be held for review, got %v", receipt.GetStatus())
+	}
+	if reviews := listBookingReviews(t, admin); len(reviews) != 1 || reviews[0].GetClientIp() != "127.0.0.1" {
+		t.Fatalf("expected the held booking to keep the client's own address, got %v", reviews)
+	}
+}
+
+func TestBookingReviewsNeedAdmin(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)),
+		server.WithScalpingRules(server.ScalpingRules{ReviewBookingsPerPayment: 1}))
+	buyer := newClient(srv, signJWT(t, "mallory", "agent"))
+	admin := newClient(srv, signJWT(t, "alice", "admin"))
+	var held *v1.Receipt
+	for _, email := range []string{"m1@example.com", "m2@example.com"} {
+		receipt, err := purchase(buyer, email, paidWith("tok_visa"))
+		if err != nil {
+			t.Fatalf("PurchaseTicket failed: %v", err)
+		}
+		held = receipt
+	}
+	if held.GetStatus() != v1.BookingStatus_BOOKING_STATUS_PENDING_REVIEW {
+		t.Fatalf("expected the second booking to be held for review, got %v", held.GetStatus())
+	}
+
+	// The buyer can neither see the queue nor approve their own booking
+	if _, err := buyer.ListBookingReviews(context.Background(), connect.NewRequest(&v1.ListBookingReviewsRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied listing reviews without the admin role, got %v", err)
+	}
+	if _, err := reviewBooking(buyer, held.GetBookingId(), v1.ReviewDecision_REVIEW_DECISION_APPROVE); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied reviewing without the admin role, got %v", err)
+	}
+	if reviews := listBookingReviews(t, admin); len(reviews) != 1 {
+		t.Fatalf("expected the booking to still be held, got %v", reviews)
+	}
+	if receipt, err := reviewBooking(admin, held.GetBookingId(), v1.ReviewDecision_REVIEW_DECISION_APPROVE); err != nil || receipt.GetStatus() != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		t.Fatalf("expected an admin to approve the booking, got %v (%v)", receipt, err)
+	}
+}
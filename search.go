@@ -0,0 +1,274 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// DEFAULT_SEARCH_LIMIT is the number of matches SearchPassengers returns when
+// the request doesn't set a limit.
+const DEFAULT_SEARCH_LIMIT = 20
+
+// MAX_SEARCH_LIMIT caps the limit of SearchPassengers.
+const MAX_SEARCH_LIMIT = 100
+
+// passengerIndex finds confirmed bookings by the words in the passenger's
+// names and email, and by seat. It is kept up to date as events are applied.
+type passengerIndex struct {
+	words    []string                       // Every indexed word, sorted for prefix lookups
+	bookings map[string]map[string]struct{} // Booking IDs by word
+	seats    map[string]string              // Booking IDs by departure and seat
+	entries  map[string]*passengerEntry     // What each booking is indexed under, by booking ID
+}
+
+type passengerEntry struct {
+	words []string
+	seat  string
+}
+
+func newPassengerIndex() *passengerIndex {
+	return &passengerIndex{
+		bookings: make(map[string]map[string]struct{}),
+		seats:    make(map[string]string),
+		entries:  make(map[string]*passengerEntry),
+	}
+}
+
+// update indexes a booking as it is now, replacing what it was indexed under
+// before. Bookings that aren't confirmed are removed from the index.
+func (x *passengerIndex) update(id string, b *booking) {
+	if old, ok := x.entries[id]; ok {
+		for _, word := range old.words {
+			delete(x.bookings[word], id)
+			if len(x.bookings[word]) == 0 {
+				delete(x.bookings, word)
+				i := sort.SearchStrings(x.words, word)
+				x.words = append(x.words[:i], x.words[i+1:]...)
+			}
+		}
+		if x.seats[old.seat] == id {
+			delete(x.seats, old.seat)
+		}
+		delete(x.entries, id)
+	}
+	if b == nil || b.status != v1.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		return
+	}
+
+	user := b.ticket.GetUser()
+	entry := &passengerEntry{seat: seatKey(b.ticket.GetDepartureId(), b.seat.GetSeatNumber())}
+	email := strings.ToLower(user.GetEmail())
+	local, _, _ := strings.Cut(email, "@")
+	for _, word := range append(searchWords(user.GetFirstName()+" "+user.GetLastName()), email, local) {
+		if _, ok := x.bookings[word]; !ok {
+			x.bookings[word] = make(map[string]struct{})
+			i := sort.SearchStrings(x.words, word)
+			x.words = append(x.words, "")
+			copy(x.words[i+1:], x.words[i:])
+			x.words[i] = word
+		}
+		if _, ok := x.bookings[word][id]; !ok {
+			x.bookings[word][id] = struct{}{}
+			entry.words = append(entry.words, word)
+		}
+	}
+	x.seats[entry.seat] = id
+	x.entries[id] = entry
+}
+
+// match returns the IDs of the bookings indexed under a word starting with
+// query, or failing that a word within a typo or two of it, along with
+// whether typos were needed.
+func (x *passengerIndex) match(query string) (map[string]struct{}, bool) {
+	ids := make(map[string]struct{})
+	for i := sort.SearchStrings(x.words, query); i < len(x.words) && strings.HasPrefix(x.words[i], query); i++ {
+		for id := range x.bookings[x.words[i]] {
+			ids[id] = struct{}{}
+		}
+	}
+	if len(ids) > 0 {
+		return ids, false
+	}
+
+	// Short words have too many neighbours for typos to be allowed
+	allowed := 0
+	switch n := len([]rune(query)); {
+	case n >= 8:
+		allowed = 2
+	case n >= 4:
+		allowed = 1
+	}
+	if allowed == 0 {
+		return ids, false
+	}
+	for _, word := range x.words {
+		if editDistance(query, word, allowed) <= allowed {
+			for id := range x.bookings[word] {
+				ids[id] = struct{}{}
+			}
+		}
+	}
+	return ids, true
+}
+
+// SearchPassengers implements the SearchPassengers method of TrainTicketingServiceHandler.
+// Only admins and conductors may search, as matches hold other passengers'
+// details.
+func (h *MyTrainTicketingServiceHandler) SearchPassengers(ctx context.Context, req *connect.Request[v1.SearchPassengersRequest]) (*connect.Response[v1.SearchPassengersResponse], error) {
+	if err := requireRole(ctx, ADMIN_ROLE, CONDUCTOR_ROLE); err != nil {
+		return nil, err
+	}
+	words := searchWords(req.Msg.GetQuery())
+	if len(words) == 0 && req.Msg.GetBookingId() == "" && req.Msg.GetSeatNumber() == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("a query, booking ID or seat number is required"))
+	}
+	if req.Msg.GetLimit() < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("limit must not be negative"))
+	}
+	limit := int(req.Msg.GetLimit())
+	if limit == 0 {
+		limit = DEFAULT_SEARCH_LIMIT
+	}
+	limit = min(limit, MAX_SEARCH_LIMIT)
+
+	// Take a read lock so other reads can go ahead at the same time
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	defer h.mu.RUnlock()
+
+	// Narrow down the candidates with each criterion in turn, counting the
+	// words that needed typos to match
+	var candidates map[string]struct{}
+	narrow := func(ids map[string]struct{}) {
+		if candidates == nil {
+			candidates = ids
+			return
+		}
+		for id := range candidates {
+			if _, ok := ids[id]; !ok {
+				delete(candidates, id)
+			}
+		}
+	}
+	if id := req.Msg.GetBookingId(); id != "" {
+		ids := make(map[string]struct{})
+		if _, ok := h.passengers.entries[id]; ok {
+			ids[id] = struct{}{}
+		}
+		narrow(ids)
+	}
+	if number := req.Msg.GetSeatNumber(); number != 0 {
+		ids := make(map[string]struct{})
+		for _, info := range h.schedule {
+			if id, ok := h.passengers.seats[seatKey(info.GetId(), number)]; ok {
+				ids[id] = struct{}{}
+			}
+		}
+		narrow(ids)
+	}
+	typos := make(map[string]int32)
+	for _, word := range words {
+		ids, fuzzy := h.passengers.match(word)
+		if fuzzy {
+			for id := range ids {
+				typos[id]++
+			}
+		}
+		narrow(ids)
+	}
+
+	// Describe each match, closest first and then in schedule and seat order
+	departureOrder := make(map[string]int)
+	for i, info := range h.schedule {
+		departureOrder[info.GetId()] = i
+	}
+	var matches []*v1.PassengerMatch
+	for id := range candidates {
+		b := h.bookings[id]
+		departureID := b.ticket.GetDepartureId()
+		if want := req.Msg.GetDepartureId(); want != "" && departureID != want {
+			continue
+		}
+		matches = append(matches, &v1.PassengerMatch{
+			Booking:   b.receipt(),
+			Section:   sectionOf(b.seat.GetSeatNumber()),
+			Departure: h.departures[departureID].info,
+			Typos:     typos[id],
+		})
+	}
+	sortPassengerMatches(matches, departureOrder)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return connect.NewResponse(&v1.SearchPassengersResponse{Matches: matches}), nil
+}
+
+// sortPassengerMatches puts the closest matches first, and then orders them by
+// departure and seat.
+func sortPassengerMatches(matches []*v1.PassengerMatch, departureOrder map[string]int) {
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.GetTypos() != b.GetTypos() {
+			return a.GetTypos() < b.GetTypos()
+		}
+		if da, db := departureOrder[a.GetDeparture().GetId()], departureOrder[b.GetDeparture().GetId()]; da != db {
+			return da < db
+		}
+		if sa, sb := a.GetBooking().GetTicket().GetSeat().GetSeatNumber(), b.GetBooking().GetTicket().GetSeat().GetSeatNumber(); sa != sb {
+			return sa < sb
+		}
+		return a.GetBooking().GetBookingId() < b.GetBooking().GetBookingId()
+	})
+}
+
+// searchWords splits text into lower case words for indexing and searching.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == ','
+	})
+}
+
+// seatKey identifies a seat on a departure in the passenger index.
+func seatKey(departureID string, seatNumber int32) string {
+	return fmt.Sprintf("%s\x00%d", departureID, seatNumber)
+}
+
+// editDistance returns the Levenshtein distance between a and b, or bound+1
+// if it is larger than bound.
+func editDistance(a, b string, bound int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > bound || -d > bound {
+		return bound + 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			best = min(best, cur[j])
+		}
+		if best > bound {
+			return bound + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,110 @@
+package ticketing_test
+
+import (
+	"context"
+	"testing"
+
+	connect "connectrpc.com/connect"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// searchPassengers returns the emails of the passengers found by req.
+func searchPassengers(t *testing.T, client ticketingv1.TrainTicketingServiceClient, req *v1.SearchPassengersRequest) ([]string, []*v1.PassengerMatch) {
+	t.Helper()
+	res, err := client.SearchPassengers(context.Background(), connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("SearchPassengers failed: %v", err)
+	}
+	var emails []string
+	for _, match := range res.Msg.GetMatches() {
+		emails = append(emails, match.GetBooking().GetTicket().GetUser().GetEmail())
+	}
+	return emails, res.Msg.GetMatches()
+}
+
+func TestSearchPassengers(t *testing.T) {
+	client := newTestClient(t)
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	johnID := purchaseTicket(t, client, "John", "Doe", "john.doe@example.org")
+	johannaID := purchaseTicket(t, client, "Johanna", "Smith-Doe", "jo@example.net")
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "John"},
+		NewSeatNumber: 15,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+
+	// Words match the start of names and emails, and every word must match
+	emails, _ := searchPassengers(t, client, &v1.SearchPassengersRequest{Query: "Jo"})
+	assertEmails(t, []string{"jo@example.net", "john.doe@example.org"}, emails)
+	emails, _ = searchPassengers(t, client, &v1.SearchPassengersRequest{Query: "doe john"})
+	assertEmails(t, []string{"john.doe@example.org"}, emails)
+	emails, _ = searchPassengers(t, client, &v1.SearchPassengersRequest{Query: "JANE@EX"})
+	assertEmails(t, []string{"jane@example.com"}, emails)
+
+	// Longer words may have a typo
+	emails, matches := searchPassengers(t, client, &v1.SearchPassengersRequest{Query: "jame"})
+	assertEmails(t, []string{"jane@example.com"}, emails)
+	if matches[0].GetTypos() != 1 {
+		t.Fatalf("expected the match to need one typo, got %d", matches[0].GetTypos())
+	}
+	if emails, _ := searchPassengers(t, client, &v1.SearchPassengersRequest{Query: "jon"}); len(emails) != 0 {
+		t.Fatalf("expected no typos to be allowed in short words, got %v", emails)
+	}
+
+	// Seats and booking IDs match exactly, and seats follow seat changes
+	emails, matches = searchPassengers(t, client, &v1.SearchPassengersRequest{SeatNumber: 15})
+	assertEmails(t, []string{"john.doe@example.org"}, emails)
+	if m := matches[0]; m.GetSection() != v1.Section_SECTION_TYPE_B || m.GetDeparture().GetId() != server.DEFAULT_DEPARTURE_ID || m.GetBooking().GetBookingId() != johnID {
+		t.Fatalf("unexpected match %v", m)
+	}
+	if emails, _ := searchPassengers(t, client, &v1.SearchPassengersRequest{SeatNumber: 2}); len(emails) != 0 {
+		t.Fatalf("expected John's old seat to be empty, got %v", emails)
+	}
+	emails, _ = searchPassengers(t, client, &v1.SearchPassengersRequest{BookingId: johannaID, Query: "smith"})
+	assertEmails(t, []string{"jo@example.net"}, emails)
+
+	// Cancelled bookings aren't found
+	if _, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{BookingId: johannaID})); err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	emails, _ = searchPassengers(t, client, &v1.SearchPassengersRequest{Query: "doe"})
+	assertEmails(t, []string{"john.doe@example.org"}, emails)
+
+	if _, err := client.SearchPassengers(context.Background(), connect.NewRequest(&v1.SearchPassengersRequest{Query: "  "})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for an empty search, got %v", err)
+	}
+}
+
+func TestSearchPassengersNeedsAdminOrConductor(t *testing.T) {
+	_, srv := startServer(t, server.WithAuthenticator(server.JWT(testJWTKey)))
+	agent := newClient(srv, signJWT(t, "agent-7", "agent"))
+	purchaseTicket(t, agent, "Jane", "Roe", "jane@example.com")
+
+	if _, err := agent.SearchPassengers(context.Background(), connect.NewRequest(&v1.SearchPassengersRequest{Query: "jane"})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("expected PermissionDenied searching without the admin or conductor role, got %v", err)
+	}
+	for _, caller := range []string{signJWT(t, "alice", "admin"), signJWT(t, "carl", "conductor")} {
+		emails, _ := searchPassengers(t, newClient(srv, caller), &v1.SearchPassengersRequest{Query: "jane"})
+		assertEmails(t, []string{"jane@example.com"}, emails)
+	}
+}
+
+func TestSearchPassengersAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	wal := openTestWAL(t, dir)
+	client := newTestClient(t, server.WithSnapshotInterval(4), server.WithLedger(wal))
+	for _, name := range []string{"Ann", "Bob", "Cat"} {
+		purchaseTicket(t, client, name, "Lee", name+"@example.com")
+	}
+	wal.Close()
+
+	// The index is rebuilt from the snapshot and the events after it
+	restored := newTestClient(t, server.WithSnapshotInterval(4), server.WithLedger(openTestWAL(t, dir)))
+	emails, _ := searchPassengers(t, restored, &v1.SearchPassengersRequest{Query: "lee"})
+	assertEmails(t, []string{"Ann@example.com", "Bob@example.com", "Cat@example.com"}, emails)
+}
@@ -0,0 +1,37 @@
+package ticketing
+
+import (
+	"context"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// ViewSeatMap implements the ViewSeatMap method of TrainTicketingServiceHandler.
+// Seats held for purchases still being paid for are shown as taken.
+func (h *MyTrainTicketingServiceHandler) ViewSeatMap(ctx context.Context, req *connect.Request[v1.ViewSeatMapRequest]) (*connect.Response[v1.ViewSeatMapResponse], error) {
+	d, err := h.lookupDeparture(req.Msg.GetDepartureId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Take a read lock so other reads can go ahead at the same time
+	if err := h.readLock(ctx); err != nil {
+		return nil, err
+	}
+	defer h.mu.RUnlock()
+
+	response := &v1.ViewSeatMapResponse{Departure: d.info}
+	for _, seat := range d.seats {
+		taken := seat.GetUser() != nil
+		if !taken {
+			response.FreeSeats++
+		}
+		response.Seats = append(response.Seats, &v1.SeatMapSeat{
+			SeatNumber: seat.GetSeatNumber(),
+			Section:    sectionOf(seat.GetSeatNumber()),
+			Taken:      taken,
+		})
+	}
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,135 @@
+package ticketing_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// takenSeats returns the numbers of the taken seats on a departure's seat map.
+func takenSeats(t *testing.T, client ticketingv1.TrainTicketingServiceClient, departureID string) []int32 {
+	t.Helper()
+	res, err := client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{DepartureId: departureID}))
+	if err != nil {
+		t.Fatalf("ViewSeatMap failed: %v", err)
+	}
+	if len(res.Msg.GetSeats()) != 20 {
+		t.Fatalf("expected 20 seats, got %d", len(res.Msg.GetSeats()))
+	}
+	var taken []int32
+	for _, seat := range res.Msg.GetSeats() {
+		if seat.GetTaken() {
+			taken = append(taken, seat.GetSeatNumber())
+		}
+	}
+	if int(res.Msg.GetFreeSeats()) != 20-len(taken) {
+		t.Fatalf("expected %d free seats, got %d", 20-len(taken), res.Msg.GetFreeSeats())
+	}
+	return taken
+}
+
+func TestViewSeatMap(t *testing.T) {
+	client := newTestClient(t)
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "John"},
+		NewSeatNumber: 15,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+
+	res, err := client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{}))
+	if err != nil {
+		t.Fatalf("ViewSeatMap failed: %v", err)
+	}
+	if seat := res.Msg.GetSeats()[14]; seat.GetSeatNumber() != 15 || seat.GetSection() != v1.Section_SECTION_TYPE_B || !seat.GetTaken() {
+		t.Fatalf("expected John's new seat to be taken, got %v", seat)
+	}
+	if taken := takenSeats(t, client, ""); fmt.Sprint(taken) != "[1 15]" {
+		t.Fatalf("expected seats 1 and 15 to be taken, got %v", taken)
+	}
+
+	_, err = client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{DepartureId: "nowhere"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound for an unknown departure, got %v", err)
+	}
+}
+
+func TestViewSeatMapFromAnyClusterMember(t *testing.T) {
+	var departures []*v1.Departure
+	for i := 0; i < 4; i++ {
+		departures = append(departures, &v1.Departure{
+			Id: fmt.Sprintf("train-%d", i), From: "London", To: "Paris",
+			DepartureTime: timestamppb.New(time.Now().Add(48 * time.Hour)), Fare: 20,
+		})
+	}
+	members := newCluster(t, 2, departures...)
+	for _, d := range departures {
+		_, err := members[0].client.PurchaseTicket(context.Background(), connect.NewRequest(&v1.PurchaseTicketRequest{
+			Ticket: &v1.Ticket{User: &v1.User{FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"}, DepartureId: d.GetId()},
+		}))
+		if err != nil {
+			t.Fatalf("PurchaseTicket failed: %v", err)
+		}
+	}
+
+	// Each departure's seats are only known to its owner
+	for _, member := range members {
+		for _, d := range departures {
+			if taken := takenSeats(t, member.client, d.GetId()); fmt.Sprint(taken) != "[1]" {
+				t.Fatalf("expected seat 1 of %s to be taken, got %v", d.GetId(), taken)
+			}
+		}
+	}
+}
+
+func TestPurchaseTicketPicksSeat(t *testing.T) {
+	client := newTestClient(t)
+	purchase := func(first string, seat int32) (*v1.Receipt, error) {
+		res, err := client.PurchaseTicket(context.Background(), connect.NewRequest(&v1.PurchaseTicketRequest{
+			Ticket: &v1.Ticket{
+				User: &v1.User{FirstName: first, LastName: "Roe", Email: first + "@example.com"},
+				Seat: &v1.Seat{SeatNumber: seat},
+			},
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return res.Msg.GetReceipt(), nil
+	}
+
+	receipt, err := purchase("Jane", 12)
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	if receipt.GetTicket().GetSeat().GetSeatNumber() != 12 {
+		t.Fatalf("expected seat 12, got %d", receipt.GetTicket().GetSeat().GetSeatNumber())
+	}
+	if _, err := purchase("John", 12); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a taken seat, got %v", err)
+	}
+	if _, err := purchase("John", 21); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument for a seat that doesn't exist, got %v", err)
+	}
+
+	// Without a seat the first free one is still booked
+	receipt, err = purchase("John", 0)
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	if receipt.GetTicket().GetSeat().GetSeatNumber() != 1 {
+		t.Fatalf("expected seat 1, got %d", receipt.GetTicket().GetSeat().GetSeatNumber())
+	}
+	if taken := takenSeats(t, client, ""); fmt.Sprint(taken) != "[1 12]" {
+		t.Fatalf("expected seats 1 and 12 to be taken, got %v", taken)
+	}
+}
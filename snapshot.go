@@ -0,0 +1,218 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	connect "connectrpc.com/connect"
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DEFAULT_SNAPSHOT_INTERVAL is how many ledger events are recorded between
+// snapshots.
+const DEFAULT_SNAPSHOT_INTERVAL = 1000
+
+// Snapshotter is implemented by ledgers that can store snapshots of the
+// handler's state. At startup the handler loads the latest snapshot and only
+// replays the events recorded after it.
+type Snapshotter interface {
+	// LoadSnapshot returns the latest snapshot, or nil if there is none.
+	LoadSnapshot() (*v1.Snapshot, error)
+	// SaveSnapshot stores a snapshot. Events up to and including the
+	// snapshot's sequence number no longer need to be replayed.
+	SaveSnapshot(snapshot *v1.Snapshot) error
+}
+
+// WithSnapshotInterval overrides DEFAULT_SNAPSHOT_INTERVAL. It has no effect
+// unless the ledger is a Snapshotter.
+func WithSnapshotInterval(events int) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.snapshotInterval = events
+	}
+}
+
+// Snapshot saves a snapshot of the current state to the ledger straight away,
+// for example before shutting down.
+func (h *MyTrainTicketingServiceHandler) Snapshot() error {
+	ctx := context.Background()
+
+	// Keep events from being appended until the snapshot is saved
+	h.ledgerMu.Lock()
+	defer h.ledgerMu.Unlock()
+
+	// Catch up with other handlers sharing the ledger
+	if err := h.catchUp(ctx); err != nil {
+		return err
+	}
+
+	return h.saveSnapshot(ctx)
+}
+
+// saveSnapshot stores a snapshot if the ledger supports them. The caller must
+// hold h.ledgerMu, so the snapshot includes every event appended, and not
+// h.mu.
+func (h *MyTrainTicketingServiceHandler) saveSnapshot(ctx context.Context) error {
+	snapshotter, ok := h.ledger.(Snapshotter)
+	if !ok {
+		return nil
+	}
+	h.mu.RLock()
+	snapshot := h.snapshot()
+	h.mu.RUnlock()
+	_, span := h.startSpan(ctx, "ledger.SaveSnapshot", attribute.Int64("ledger.sequence", h.sequence))
+	err := snapshotter.SaveSnapshot(snapshot)
+	endSpan(span, err)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to save snapshot: %w", err))
+	}
+	h.snapshotSequence = h.sequence
+	return nil
+}
+
+// maybeSnapshot saves a snapshot once enough events have been recorded since
+// the last one. The caller must hold h.ledgerMu and not h.mu.
+func (h *MyTrainTicketingServiceHandler) maybeSnapshot(ctx context.Context) {
+	if h.snapshotInterval <= 0 || h.sequence-h.snapshotSequence < int64(h.snapshotInterval) {
+		return
+	}
+	// The event is already in the ledger, so a failed snapshot only means
+	// more events to replay; it is retried after the next event
+	_ = h.saveSnapshot(ctx)
+}
+
+// snapshot copies the handler's state. The caller must hold h.mu.
+func (h *MyTrainTicketingServiceHandler) snapshot() *v1.Snapshot {
+	snapshot := &v1.Snapshot{
+		Sequence:    h.sequence,
+		TakenAt:     timestamppb.New(h.now()),
+		Redemptions: make(map[string]int32, len(h.redemptions)),
+	}
+	for code, n := range h.redemptions {
+		snapshot.Redemptions[code] = int32(n)
+	}
+	for _, user := range h.users {
+		snapshot.Users = append(snapshot.Users, proto.Clone(user).(*v1.User))
+	}
+	for _, b := range h.bookings {
+		record := &v1.BookingRecord{
+			Id:                 b.id,
+			Ticket:             proto.Clone(b.ticket).(*v1.Ticket),
+			Status:             b.status,
+			PurchasedAt:        timestamppb.New(b.purchasedAt),
+			RefundAmount:       b.refundAmount,
+			PreviousBookingIds: b.previousIDs,
+			PaymentMethod:      b.paymentMethod,
+			Payments:           b.payments,
+			Version:            b.version,
+			Account:            b.account,
+			ClientIp:           b.clientIP,
+			PaymentFingerprint: b.fingerprint,
+			ReviewReason:       b.reviewReason,
+		}
+		if !b.cancelledAt.IsZero() {
+			record.CancelledAt = timestamppb.New(b.cancelledAt)
+		}
+		snapshot.Bookings = append(snapshot.Bookings, record)
+	}
+
+	// Keep snapshots of the same state byte-for-byte identical
+	sort.Slice(snapshot.Users, func(i, j int) bool { return snapshot.Users[i].GetEmail() < snapshot.Users[j].GetEmail() })
+	sort.Slice(snapshot.Bookings, func(i, j int) bool { return snapshot.Bookings[i].GetId() < snapshot.Bookings[j].GetId() })
+	return snapshot
+}
+
+// restore replaces the handler's state with a snapshot. Bookings that still
+// hold a seat are put back in it.
+func (h *MyTrainTicketingServiceHandler) restore(snapshot *v1.Snapshot) error {
+	for _, user := range snapshot.GetUsers() {
+		h.users[user.GetEmail()] = user
+	}
+	for code, n := range snapshot.GetRedemptions() {
+		h.redemptions[code] = int(n)
+	}
+	for _, record := range snapshot.GetBookings() {
+		b := &booking{
+			id:            record.GetId(),
+			ticket:        record.GetTicket(),
+			status:        record.GetStatus(),
+			purchasedAt:   record.GetPurchasedAt().AsTime(),
+			refundAmount:  record.GetRefundAmount(),
+			previousIDs:   record.GetPreviousBookingIds(),
+			paymentMethod: record.GetPaymentMethod(),
+			payments:      record.GetPayments(),
+			version:       record.GetVersion(),
+			account:       record.GetAccount(),
+			clientIP:      record.GetClientIp(),
+			fingerprint:   record.GetPaymentFingerprint(),
+			reviewReason:  record.GetReviewReason(),
+		}
+		if record.GetCancelledAt() != nil {
+			b.cancelledAt = record.GetCancelledAt().AsTime()
+		}
+		if b.holdsSeat() {
+			seat, err := h.eventSeat(b.ticket.GetDepartureId(), b.ticket.GetSeat().GetSeatNumber())
+			if err != nil {
+				return fmt.Errorf("booking %q: %w", b.id, err)
+			}
+			seat.User = b.ticket.GetUser()
+			b.seat = seat
+			b.ticket.Seat = seat
+		} else {
+			b.seat = b.ticket.GetSeat()
+		}
+		h.addBooking(b)
+		h.passengers.update(b.id, b)
+	}
+	h.sequence = snapshot.GetSequence()
+	h.snapshotSequence = snapshot.GetSequence()
+	return nil
+}
+
+// recoverState rebuilds the handler's state from the latest snapshot, if the
+// ledger has one, and the events recorded after it.
+func (h *MyTrainTicketingServiceHandler) recoverState(ctx context.Context) error {
+	if snapshotter, ok := h.ledger.(Snapshotter); ok {
+		snapshot, err := snapshotter.LoadSnapshot()
+		if err != nil {
+			return err
+		}
+		if snapshot != nil {
+			if err := h.restore(snapshot); err != nil {
+				return fmt.Errorf("failed to restore snapshot: %w", err)
+			}
+		}
+	}
+
+	// Events the snapshot already includes are skipped
+	replay := h.ledger.Replay
+	if shared, ok := h.ledger.(SharedLedger); ok {
+		replay = func(fn func(*v1.LedgerEvent) error) error { return shared.ReplaySince(h.sequence, fn) }
+	}
+	if err := replay(h.applyNext); err != nil {
+		return err
+	}
+
+	// Seats held by purchases that were interrupted before they were paid
+	// for are given back. Other handlers sharing the ledger may still be
+	// taking payment for theirs, so those are left alone.
+	if _, ok := h.ledger.(SharedLedger); ok {
+		return nil
+	}
+	var held []string
+	for id, b := range h.bookings {
+		if b.status == v1.BookingStatus_BOOKING_STATUS_HELD {
+			held = append(held, id)
+		}
+	}
+	sort.Strings(held)
+	for _, id := range held {
+		if err := h.appendEvent(ctx, &v1.LedgerEvent{Event: &v1.LedgerEvent_HoldReleased{HoldReleased: &v1.HoldReleased{BookingId: id}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package ticketing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	connect "connectrpc.com/connect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+)
+
+// TRACER_NAME names the tracer the handler's spans come from.
+const TRACER_NAME = "github.com/parandor/ticketing"
+
+// tracePropagator reads the trace context of incoming requests from their W3C
+// traceparent and baggage headers, and writes it into requests forwarded to
+// other cluster members.
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// WithTracerProvider sets where the handler's OpenTelemetry spans go. It
+// defaults to the global provider, which drops them unless one is set with
+// otel.SetTracerProvider.
+//
+// Every RPC gets a server span, continuing the trace in the request's
+// traceparent header when it has one. Its children time authentication,
+// seat allocation, pricing, payments and each call to the ledger.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(h *MyTrainTicketingServiceHandler) {
+		h.tracer = provider.Tracer(TRACER_NAME)
+	}
+}
+
+// startSpan starts a span for part of a request, as a child of the span in
+// ctx.
+func (h *MyTrainTicketingServiceHandler) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return h.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan marks span as failed when err is set, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// eventName names the kind of a ledger event, such as seat_held.
+func eventName(event *v1.LedgerEvent) string {
+	msg := event.ProtoReflect()
+	field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("event"))
+	if field == nil {
+		return ""
+	}
+	return string(field.Name())
+}
+
+// restRouteKey is the context key of the template of the REST route a call
+// came in on.
+type restRouteKey struct{}
+
+// withTracing starts the server span of every RPC, so authentication is
+// timed as part of it. Spans are named after the RPC, and paths that aren't
+// one of the service's RPCs share a span named after the HTTP method, so
+// callers can't make up span names. Calls made through the REST gateway also
+// note the route they came in on.
+func (h *MyTrainTicketingServiceHandler) withTracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := tracePropagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		system := "connect_rpc"
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			system = "grpc"
+		}
+		attrs := []attribute.KeyValue{attribute.String("rpc.system", system)}
+		name := r.Method
+		procedure := strings.TrimPrefix(r.URL.Path, "/")
+		if service, method, _ := strings.Cut(procedure, "/"); isProcedure(service, method) {
+			name = procedure
+			attrs = append(attrs, attribute.String("rpc.service", service), attribute.String("rpc.method", method))
+		}
+		if route, ok := r.Context().Value(restRouteKey{}).(string); ok {
+			attrs = append(attrs, attribute.String("http.route", route))
+		}
+		ctx, span := h.tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isProcedure reports whether method is an RPC of service, the ticketing
+// service.
+func isProcedure(service, method string) bool {
+	if service != ticketingv1.TrainTicketingServiceName {
+		return false
+	}
+	methods := v1.File_proto_train_ticketing_v1_ticketing_proto.Services().ByName("TrainTicketingService").Methods()
+	return methods.ByName(protoreflect.Name(method)) != nil
+}
+
+// traceAuthentication times authenticate in a span of its own.
+func (h *MyTrainTicketingServiceHandler) traceAuthentication(authenticate Authenticator) Authenticator {
+	return func(r *http.Request) (*Identity, error) {
+		_, span := h.startSpan(r.Context(), "authenticate")
+		identity, err := authenticate(r)
+		endSpan(span, err)
+		return identity, err
+	}
+}
+
+// tracingInterceptor records how each RPC ended on its server span.
+type tracingInterceptor struct{}
+
+// WrapUnary implements connect.Interceptor.
+func (tracingInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		recordCode(trace.SpanFromContext(ctx), err)
+		return res, err
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor. The handler makes no
+// streaming calls, so there's nothing to record.
+func (tracingInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (tracingInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		err := next(ctx, conn)
+		recordCode(trace.SpanFromContext(ctx), err)
+		return err
+	}
+}
+
+// recordCode marks span as failed with the code of err, when err is set.
+func recordCode(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.SetAttributes(attribute.String("rpc.connect_rpc.error_code", connect.CodeOf(err).String()))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,193 @@
+package ticketing_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	connect "connectrpc.com/connect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	server "github.com/parandor/ticketing"
+	ticketingv1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1/train_ticketingv1connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// newTestTracer returns an option sending a handler's spans to the returned
+// exporter.
+func newTestTracer(t *testing.T) (server.Option, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return server.WithTracerProvider(provider), exporter
+}
+
+// rpcSpan waits for the server span of an RPC to end, as that can happen just
+// after the response is sent, and returns it with every span ended so far.
+func rpcSpan(t *testing.T, exporter *tracetest.InMemoryExporter, procedure string) (tracetest.SpanStub, tracetest.SpanStubs) {
+	t.Helper()
+	return waitForSpan(t, exporter, strings.TrimPrefix(procedure, "/"))
+}
+
+// waitForSpan waits for a span with the given name to end, and returns it
+// with every span ended so far.
+func waitForSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) (tracetest.SpanStub, tracetest.SpanStubs) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		spans := exporter.GetSpans()
+		for _, span := range spans {
+			if span.Name == name {
+				return span, spans
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no span named %s", name)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// childNames returns the names of the spans whose parent is parent, in the
+// order they ended.
+func childNames(spans tracetest.SpanStubs, parent tracetest.SpanStub) []string {
+	var names []string
+	for _, span := range spans {
+		if span.Parent.SpanID() == parent.SpanContext.SpanID() {
+			names = append(names, span.Name)
+		}
+	}
+	return names
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) string {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracingPurchase(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	client := newTestClient(t, tracer)
+
+	// The client's trace is continued from its traceparent header
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	parentID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	req := connect.NewRequest(&v1.PurchaseTicketRequest{
+		Ticket: &v1.Ticket{User: &v1.User{FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"}, DiscountCode: "WOW1"},
+	})
+	req.Header().Set("traceparent", "00-"+traceID.String()+"-"+parentID.String()+"-01")
+	if _, err := client.PurchaseTicket(context.Background(), req); err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+
+	root, spans := rpcSpan(t, exporter, ticketingv1.TrainTicketingServicePurchaseTicketProcedure)
+	if root.SpanKind != trace.SpanKindServer {
+		t.Fatalf("expected a server span, got %v", root.SpanKind)
+	}
+	if root.SpanContext.TraceID() != traceID || root.Parent.SpanID() != parentID || !root.Parent.IsRemote() {
+		t.Fatalf("expected the span to continue the client's trace, got trace %s with parent %s", root.SpanContext.TraceID(), root.Parent.SpanID())
+	}
+	if method := spanAttribute(root, "rpc.method"); method != "PurchaseTicket" {
+		t.Fatalf("expected rpc.method PurchaseTicket, got %q", method)
+	}
+	for _, span := range spans {
+		if span.SpanContext.TraceID() != traceID {
+			t.Fatalf("expected span %s to be part of the client's trace", span.Name)
+		}
+	}
+
+	want := []string{"authenticate", "allocateSeat", "price", "ledger.Append", "payment.Charge", "ledger.Append", "ledger.Append"}
+	got := childNames(spans, root)
+	if len(got) != len(want) {
+		t.Fatalf("expected spans %v under the purchase, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected spans %v under the purchase, got %v", want, got)
+		}
+	}
+
+	var events []string
+	for _, span := range spans {
+		if span.Name == "ledger.Append" {
+			events = append(events, spanAttribute(span, "ledger.event"))
+		}
+		if span.Name == "price" && spanAttribute(span, "ticketing.price") != "18" {
+			t.Fatalf("expected a price of 18 after the discount, got %s", spanAttribute(span, "ticketing.price"))
+		}
+	}
+	if len(events) != 3 || events[0] != "seat_held" || events[1] != "discount_redeemed" || events[2] != "booking_confirmed" {
+		t.Fatalf("expected the hold, discount and confirmation to be recorded, got %v", events)
+	}
+}
+
+func TestTracingFailures(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	client := newTestClient(t, tracer)
+	_, err := client.ViewSeatMap(context.Background(), connect.NewRequest(&v1.ViewSeatMapRequest{DepartureId: "nowhere"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	root, _ := rpcSpan(t, exporter, ticketingv1.TrainTicketingServiceViewSeatMapProcedure)
+	if root.Status.Code != codes.Error || spanAttribute(root, "rpc.connect_rpc.error_code") != "not_found" {
+		t.Fatalf("expected the span to record the failure, got %v %v", root.Status, root.Attributes)
+	}
+
+	// A request without a token fails in the authenticate span
+	tracer, exporter = newTestTracer(t)
+	_, srv := startServer(t, tracer)
+	unauthenticated := newClient(srv, "")
+	if code := viewAdminDetailsCode(unauthenticated); code != connect.CodeUnauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", code)
+	}
+	root, spans := rpcSpan(t, exporter, ticketingv1.TrainTicketingServiceViewAdminDetailsProcedure)
+	for _, span := range spans {
+		if span.Name == "authenticate" {
+			if span.Parent.SpanID() != root.SpanContext.SpanID() || span.Status.Code != codes.Error {
+				t.Fatalf("expected a failed authenticate span under the RPC, got %v", span.Status)
+			}
+			return
+		}
+	}
+	t.Fatal("no authenticate span")
+}
+
+func TestTracingSpanNames(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	_, srv := startServer(t, tracer)
+
+	// REST calls are named after their RPC and note the route they matched
+	if status, body := restCall(t, srv, http.MethodGet, "/v1/departures/"+server.DEFAULT_DEPARTURE_ID+"/seats", "", server.DEMO_AUTH_TOKEN); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+	root, _ := rpcSpan(t, exporter, ticketingv1.TrainTicketingServiceViewSeatMapProcedure)
+	if route := spanAttribute(root, "http.route"); route != "/v1/departures/{departure_id}/seats" {
+		t.Fatalf("expected the route template, got %q", route)
+	}
+
+	// Paths that aren't RPCs don't make up span names of their own
+	if status, _ := restCall(t, srv, http.MethodPost, "/"+ticketingv1.TrainTicketingServiceName+"/NoSuchMethod-12345", "{}", server.DEMO_AUTH_TOKEN); status != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown RPC, got %d", status)
+	}
+	root, spans := waitForSpan(t, exporter, http.MethodPost)
+	if method := spanAttribute(root, "rpc.method"); method != "" {
+		t.Fatalf("expected no rpc.method for an unknown RPC, got %q", method)
+	}
+	for _, span := range spans {
+		if strings.Contains(span.Name, "NoSuchMethod") {
+			t.Fatalf("expected no span named after the path, got %s", span.Name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package ticketing_test
+
+import (
+	"context"
+	"testing"
+
+	connect "connectrpc.com/connect"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+func TestBookingVersionConflicts(t *testing.T) {
+	client := newTestClient(t)
+
+	purchase, err := client.PurchaseTicket(context.Background(), connect.NewRequest(&v1.PurchaseTicketRequest{
+		Ticket: &v1.Ticket{User: &v1.User{FirstName: "Jane", LastName: "Roe", Email: "jane@example.com"}},
+	}))
+	if err != nil {
+		t.Fatalf("PurchaseTicket failed: %v", err)
+	}
+	receipt := purchase.Msg.GetReceipt()
+	if receipt.GetVersion() != 1 {
+		t.Fatalf("expected new booking at version 1, got %d", receipt.GetVersion())
+	}
+
+	// The first admin moves the user and bumps the version
+	modified, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:            &v1.User{FirstName: "Jane"},
+		NewSeatNumber:   15,
+		ExpectedVersion: 1,
+	}))
+	if err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	if modified.Msg.GetReceipt().GetVersion() != 2 || modified.Msg.GetReceipt().GetTicket().GetSeat().GetSeatNumber() != 15 {
+		t.Fatalf("expected seat 15 at version 2, got %v", modified.Msg.GetReceipt())
+	}
+
+	// A second admin working from the stale receipt is turned away
+	_, err = client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:            &v1.User{FirstName: "Jane"},
+		NewSeatNumber:   3,
+		ExpectedVersion: 1,
+	}))
+	if connect.CodeOf(err) != connect.CodeAborted {
+		t.Fatalf("expected Aborted for stale ModifySeat, got %v", err)
+	}
+	_, err = client.RemoveUser(context.Background(), connect.NewRequest(&v1.RemoveUserRequest{
+		User:            &v1.User{FirstName: "Jane"},
+		ExpectedVersion: 1,
+	}))
+	if connect.CodeOf(err) != connect.CodeAborted {
+		t.Fatalf("expected Aborted for stale RemoveUser, got %v", err)
+	}
+	_, err = client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{
+		BookingId:       receipt.GetBookingId(),
+		ExpectedVersion: 1,
+	}))
+	if connect.CodeOf(err) != connect.CodeAborted {
+		t.Fatalf("expected Aborted for stale CancelBooking, got %v", err)
+	}
+
+	// Requests without an expected version, or with the current one, still go through
+	cancelled, err := client.CancelBooking(context.Background(), connect.NewRequest(&v1.CancelBookingRequest{
+		BookingId:       receipt.GetBookingId(),
+		ExpectedVersion: 2,
+	}))
+	if err != nil {
+		t.Fatalf("CancelBooking failed: %v", err)
+	}
+	if cancelled.Msg.GetCancellationReceipt().GetVersion() != 3 {
+		t.Fatalf("expected cancelled booking at version 3, got %d", cancelled.Msg.GetCancellationReceipt().GetVersion())
+	}
+}
@@ -0,0 +1,383 @@
+package ticketing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// WAL_RECORD_HEADER_SIZE is the size of the length and checksum written in
+// front of every record in the write-ahead log.
+const WAL_RECORD_HEADER_SIZE = 8
+
+// WAL_MAX_RECORD_SIZE is the largest record the write-ahead log accepts.
+const WAL_MAX_RECORD_SIZE = 16 * 1024 * 1024
+
+// ErrCorruptWAL is returned when the write-ahead log is damaged somewhere other
+// than its tail, so events can't be recovered without losing later ones.
+var ErrCorruptWAL = errors.New("write-ahead log is corrupt")
+
+// errTornRecord marks a record cut short by a crash while it was written.
+var errTornRecord = errors.New("torn record")
+
+var walChecksum = crc32.MakeTable(crc32.Castagnoli)
+
+// WAL is a Ledger that keeps events in a directory of append-only segment
+// files. Every Append is fsync'd before it returns, so an event the handler
+// has applied survives a crash. Saving a snapshot starts a new segment and
+// deletes the segments and snapshots it replaces, keeping the directory
+// compact.
+//
+// Each record is a little-endian uint32 length, a CRC-32C of the payload and
+// the binary-encoded event. A record left half-written by a crash at the end
+// of the newest segment is truncated when the WAL is opened; damage anywhere
+// else is reported as ErrCorruptWAL.
+type WAL struct {
+	mu      sync.Mutex
+	dir     string
+	segment int      // Number of the segment being appended to
+	file    *os.File // Segment being appended to
+	size    int64    // Size of the segment after its last complete record
+}
+
+// OpenWAL opens the write-ahead log in dir, creating the directory if it
+// doesn't exist.
+func OpenWAL(dir string) (*WAL, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create write-ahead log directory: %w", err)
+	}
+	segments, err := listWALFiles(dir, "wal-", ".log")
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WAL{dir: dir, segment: 1}
+	if len(segments) > 0 {
+		w.segment = segments[len(segments)-1]
+		// Drop whatever a crash left half-written at the end of the newest segment
+		if err := repairSegment(w.segmentPath(w.segment)); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.openSegment(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Append implements Ledger.
+func (w *WAL) Append(event *v1.LedgerEvent) error {
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode ledger event: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return errors.New("write-ahead log is closed")
+	}
+	record := frameRecord(payload)
+	if _, err := w.file.Write(record); err != nil {
+		// Don't leave a partial record in front of the next one
+		w.file.Truncate(w.size)
+		return fmt.Errorf("failed to write ledger event: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		w.file.Truncate(w.size)
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	w.size += int64(len(record))
+	return nil
+}
+
+// Replay implements Ledger. Events already covered by the latest snapshot may
+// be replayed too; the handler skips them.
+func (w *WAL) Replay(fn func(*v1.LedgerEvent) error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	segments, err := listWALFiles(w.dir, "wal-", ".log")
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		data, err := os.ReadFile(w.segmentPath(segment))
+		if err != nil {
+			return fmt.Errorf("failed to read write-ahead log: %w", err)
+		}
+		_, err = readRecords(data, func(payload []byte) error {
+			event := &v1.LedgerEvent{}
+			if err := proto.Unmarshal(payload, event); err != nil {
+				return fmt.Errorf("%w: %v", ErrCorruptWAL, err)
+			}
+			return fn(event)
+		})
+		if errors.Is(err, errTornRecord) {
+			return fmt.Errorf("%w: segment %d ends with a partial record", ErrCorruptWAL, segment)
+		}
+		if err != nil {
+			return fmt.Errorf("segment %d: %w", segment, err)
+		}
+	}
+	return nil
+}
+
+// LoadSnapshot implements Snapshotter.
+func (w *WAL) LoadSnapshot() (*v1.Snapshot, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	snapshots, err := listWALFiles(w.dir, "snapshot-", ".pb")
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(w.snapshotPath(snapshots[len(snapshots)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snapshot *v1.Snapshot
+	_, err = readRecords(data, func(payload []byte) error {
+		snapshot = &v1.Snapshot{}
+		return proto.Unmarshal(payload, snapshot)
+	})
+	if err != nil || snapshot == nil {
+		return nil, fmt.Errorf("snapshot %d is corrupt: %v", snapshots[len(snapshots)-1], err)
+	}
+	return snapshot, nil
+}
+
+// SaveSnapshot implements Snapshotter. The snapshot must include every event
+// appended so far.
+func (w *WAL) SaveSnapshot(snapshot *v1.Snapshot) error {
+	payload, err := proto.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return errors.New("write-ahead log is closed")
+	}
+
+	// Write the snapshot under a temporary name so a crash never leaves a
+	// partial snapshot behind
+	path := w.snapshotPath(int(snapshot.GetSequence()))
+	if err := writeFileSync(path+".tmp", frameRecord(payload)); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := syncDir(w.dir); err != nil {
+		return err
+	}
+
+	// Start a new segment for the events after the snapshot
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	previous := w.segment
+	w.segment++
+	if err := w.openSegment(); err != nil {
+		return err
+	}
+
+	// Everything in the older segments and snapshots is covered by this snapshot
+	segments, err := listWALFiles(w.dir, "wal-", ".log")
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		if segment <= previous {
+			os.Remove(w.segmentPath(segment))
+		}
+	}
+	snapshots, err := listWALFiles(w.dir, "snapshot-", ".pb")
+	if err != nil {
+		return err
+	}
+	for _, sequence := range snapshots {
+		if sequence < int(snapshot.GetSequence()) {
+			os.Remove(w.snapshotPath(sequence))
+		}
+	}
+	return syncDir(w.dir)
+}
+
+// Close closes the segment being appended to.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *WAL) segmentPath(segment int) string {
+	return filepath.Join(w.dir, fmt.Sprintf("wal-%010d.log", segment))
+}
+
+func (w *WAL) snapshotPath(sequence int) string {
+	return filepath.Join(w.dir, fmt.Sprintf("snapshot-%020d.pb", sequence))
+}
+
+// openSegment opens the current segment for appending, creating it if needed.
+func (w *WAL) openSegment() error {
+	file, err := os.OpenFile(w.segmentPath(w.segment), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+	if err := syncDir(w.dir); err != nil {
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// frameRecord prefixes payload with its length and checksum.
+func frameRecord(payload []byte) []byte {
+	record := make([]byte, WAL_RECORD_HEADER_SIZE, WAL_RECORD_HEADER_SIZE+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(payload, walChecksum))
+	return append(record, payload...)
+}
+
+// readRecords calls fn for every record in data and returns how many bytes
+// held valid records. A damaged record that runs to the end of data, or is
+// followed only by zeros, is reported as errTornRecord; any other damage is
+// ErrCorruptWAL.
+func readRecords(data []byte, fn func(payload []byte) error) (int, error) {
+	offset := 0
+	for offset < len(data) {
+		rest := data[offset:]
+		// Damage is only a torn write when nothing but zeros follows it
+		torn := func(after []byte, reason string) error {
+			if len(bytes.Trim(after, "\x00")) == 0 {
+				return errTornRecord
+			}
+			return fmt.Errorf("%w: %s at offset %d", ErrCorruptWAL, reason, offset)
+		}
+
+		if len(rest) < WAL_RECORD_HEADER_SIZE {
+			return offset, errTornRecord
+		}
+		length := binary.LittleEndian.Uint32(rest[0:4])
+		if length == 0 || length > WAL_MAX_RECORD_SIZE {
+			return offset, torn(rest, "invalid record length")
+		}
+		end := WAL_RECORD_HEADER_SIZE + int(length)
+		if end > len(rest) {
+			return offset, errTornRecord
+		}
+		payload := rest[WAL_RECORD_HEADER_SIZE:end]
+		if crc32.Checksum(payload, walChecksum) != binary.LittleEndian.Uint32(rest[4:8]) {
+			return offset, torn(rest[end:], "checksum mismatch")
+		}
+		if err := fn(payload); err != nil {
+			return offset, err
+		}
+		offset += end
+	}
+	return offset, nil
+}
+
+// repairSegment truncates a segment after its last complete record.
+func repairSegment(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read write-ahead log: %w", err)
+	}
+	valid, err := readRecords(data, func([]byte) error { return nil })
+	if !errors.Is(err, errTornRecord) {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to repair write-ahead log: %w", err)
+	}
+	defer file.Close()
+	if err := file.Truncate(int64(valid)); err != nil {
+		return fmt.Errorf("failed to repair write-ahead log: %w", err)
+	}
+	return file.Sync()
+}
+
+// listWALFiles returns the numbers in the names of the files in dir that have
+// prefix and suffix, in ascending order.
+func listWALFiles(dir, prefix, suffix string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list write-ahead log: %w", err)
+	}
+	var numbers []int
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix))
+		if err != nil {
+			continue
+		}
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// writeFileSync writes data to path and waits for it to reach the disk.
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir makes file creations, renames and removals in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to sync write-ahead log directory: %w", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync write-ahead log directory: %w", err)
+	}
+	return nil
+}
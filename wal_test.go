@@ -0,0 +1,181 @@
+package ticketing_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	connect "connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	server "github.com/parandor/ticketing"
+
+	v1 "github.com/parandor/ticketing/internal/gen/proto/train_ticketing/v1"
+)
+
+// openTestWAL opens the write-ahead log in dir until the test ends.
+func openTestWAL(t *testing.T, dir string) *server.WAL {
+	t.Helper()
+	wal, err := server.OpenWAL(dir)
+	if err != nil {
+		t.Fatalf("OpenWAL failed: %v", err)
+	}
+	t.Cleanup(func() { wal.Close() })
+	return wal
+}
+
+// walSegment returns the path of the only segment in dir.
+func walSegment(t *testing.T, dir string) string {
+	t.Helper()
+	segments, err := filepath.Glob(filepath.Join(dir, "wal-*.log"))
+	if err != nil || len(segments) != 1 {
+		t.Fatalf("expected a single segment, got %v (%v)", segments, err)
+	}
+	return segments[0]
+}
+
+// walRecordOffsets returns the offset of every record in a segment.
+func walRecordOffsets(t *testing.T, path string) []int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	var offsets []int
+	for offset := 0; offset < len(data); {
+		offsets = append(offsets, offset)
+		offset += server.WAL_RECORD_HEADER_SIZE + int(binary.LittleEndian.Uint32(data[offset:]))
+	}
+	return offsets
+}
+
+func assertSameSeats(t *testing.T, want, got []*v1.Seat) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d seats, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Fatalf("seat %d differs: want %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestWALRecoversFromSnapshotAndTail(t *testing.T) {
+	dir := t.TempDir()
+	wal := openTestWAL(t, dir)
+	client := newTestClient(t, server.WithSnapshotInterval(6), server.WithLedger(wal))
+
+	// Seven purchases and a seat change write fifteen events, so snapshots
+	// are taken after events 6 and 12 and the log only holds the last three
+	for _, name := range []string{"Ann", "Bob", "Cat", "Dan", "Eve", "Fay", "Gus"} {
+		purchaseTicket(t, client, name, "Lee", name+"@example.com")
+	}
+	if _, err := client.ModifySeat(context.Background(), connect.NewRequest(&v1.ModifySeatRequest{
+		User:          &v1.User{FirstName: "Bob"},
+		NewSeatNumber: 18,
+	})); err != nil {
+		t.Fatalf("ModifySeat failed: %v", err)
+	}
+	want := viewAdminSeats(t, client)
+	wal.Close()
+
+	snapshots, _ := filepath.Glob(filepath.Join(dir, "snapshot-*.pb"))
+	if len(snapshots) != 1 || filepath.Base(snapshots[0]) != "snapshot-00000000000000000012.pb" {
+		t.Fatalf("expected only the snapshot after event 12, got %v", snapshots)
+	}
+	if records := walRecordOffsets(t, walSegment(t, dir)); len(records) != 3 {
+		t.Fatalf("expected 3 events after the snapshot, got %d", len(records))
+	}
+
+	restored := newTestClient(t, server.WithSnapshotInterval(6), server.WithLedger(openTestWAL(t, dir)))
+	assertSameSeats(t, want, viewAdminSeats(t, restored))
+}
+
+func TestWALRecoversFromTruncatedTail(t *testing.T) {
+	dir := t.TempDir()
+	wal := openTestWAL(t, dir)
+	client := newTestClient(t, server.WithLedger(wal))
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	wal.Close()
+
+	// Cut the last record, John's confirmation, in half as if the process
+	// crashed while writing it
+	segment := walSegment(t, dir)
+	offsets := walRecordOffsets(t, segment)
+	info, _ := os.Stat(segment)
+	last := offsets[len(offsets)-1]
+	if err := os.Truncate(segment, int64(last)+(info.Size()-int64(last))/2); err != nil {
+		t.Fatalf("Truncate failed: %v", err)
+	}
+
+	// The torn record is dropped and John's unpaid hold on seat 2 is released
+	wal = openTestWAL(t, dir)
+	restored := newTestClient(t, server.WithLedger(wal))
+	seats := viewAdminSeats(t, restored)
+	if len(seats) != 1 || seats[0].GetUser().GetFirstName() != "Jane" {
+		t.Fatalf("expected only Jane to be seated, got %v", seats)
+	}
+	purchaseTicket(t, restored, "Mary", "Major", "mary@example.com")
+	want := viewAdminSeats(t, restored)
+	if len(want) != 2 || want[1].GetSeatNumber() != 2 {
+		t.Fatalf("expected Mary to get the released seat, got %v", want)
+	}
+	wal.Close()
+
+	// The repaired log keeps working across further restarts
+	again := newTestClient(t, server.WithLedger(openTestWAL(t, dir)))
+	assertSameSeats(t, want, viewAdminSeats(t, again))
+}
+
+func TestWALRecoversFromCorruptTail(t *testing.T) {
+	dir := t.TempDir()
+	wal := openTestWAL(t, dir)
+	client := newTestClient(t, server.WithLedger(wal))
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	want := viewAdminSeats(t, client)
+	purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	wal.Close()
+
+	// Garble John's confirmation, the last record, and pad the file with the
+	// zeros some filesystems leave after a crash
+	segment := walSegment(t, dir)
+	offsets := walRecordOffsets(t, segment)
+	data, _ := os.ReadFile(segment)
+	data[len(data)-1] ^= 0xff
+	data = append(data, make([]byte, 64)...)
+	if err := os.WriteFile(segment, data, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	restored := newTestClient(t, server.WithLedger(openTestWAL(t, dir)))
+	assertSameSeats(t, want, viewAdminSeats(t, restored))
+	if records := walRecordOffsets(t, segment); len(records) != len(offsets) {
+		t.Fatalf("expected the corrupt record to be replaced by a hold release, got %d records", len(records))
+	}
+}
+
+func TestWALRejectsCorruptionBeforeTail(t *testing.T) {
+	dir := t.TempDir()
+	wal := openTestWAL(t, dir)
+	client := newTestClient(t, server.WithLedger(wal))
+	purchaseTicket(t, client, "Jane", "Roe", "jane@example.com")
+	purchaseTicket(t, client, "John", "Doe", "john@example.com")
+	wal.Close()
+
+	// Damage the first record; dropping it would silently lose later events
+	segment := walSegment(t, dir)
+	data, _ := os.ReadFile(segment)
+	data[server.WAL_RECORD_HEADER_SIZE] ^= 0xff
+	if err := os.WriteFile(segment, data, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if _, err := server.OpenWAL(dir); !errors.Is(err, server.ErrCorruptWAL) {
+		t.Fatalf("expected ErrCorruptWAL, got %v", err)
+	}
+}